@@ -3,6 +3,7 @@ package playwright
 import (
 	"errors"
 	"fmt"
+	"io"
 )
 
 type artifactImpl struct {
@@ -35,7 +36,10 @@ func (a *artifactImpl) SaveAs(path string) error {
 	if err != nil {
 		return err
 	}
-	stream := fromChannel(streamChannel).(*streamImpl)
+	stream, ok := fromNullableChannel(streamChannel).(*streamImpl)
+	if !ok {
+		return errors.New("artifact stream is not available")
+	}
 	return stream.SaveAs(path)
 }
 
@@ -57,16 +61,28 @@ func (a *artifactImpl) Cancel() error {
 	return err
 }
 
-func (a *artifactImpl) ReadIntoBuffer() ([]byte, error) {
+// Stream returns a reader over the artifact contents. Chunks are fetched from the driver
+// as they are read, so callers can copy large artifacts without buffering them fully.
+// The caller must close the returned reader.
+func (a *artifactImpl) Stream() (io.ReadCloser, error) {
 	streamChannel, err := a.channel.Send("stream")
 	if err != nil {
 		return nil, err
 	}
-	stream := fromNullableChannel(streamChannel)
-	if stream == nil {
-		return nil, nil
+	stream, ok := fromNullableChannel(streamChannel).(*streamImpl)
+	if !ok {
+		return nil, errors.New("artifact stream is not available")
+	}
+	return stream, nil
+}
+
+func (a *artifactImpl) ReadIntoBuffer() ([]byte, error) {
+	stream, err := a.Stream()
+	if err != nil {
+		return nil, err
 	}
-	return stream.(*streamImpl).ReadAll()
+	defer stream.Close()
+	return io.ReadAll(stream)
 }
 
 func newArtifact(parent *channelOwner, objectType string, guid string, initializer map[string]interface{}) *artifactImpl {
@@ -103,7 +103,7 @@ func toExpectedTextValues(
 	matchSubstring bool,
 	normalizeWhiteSpace bool,
 	ignoreCase *bool,
-) []expectedTextValue {
+) ([]expectedTextValue, error) {
 	var out []expectedTextValue
 	for _, item := range items {
 		switch item := item.(type) {
@@ -115,7 +115,10 @@ func toExpectedTextValues(
 				IgnoreCase:          ignoreCase,
 			})
 		case *regexp.Regexp:
-			pattern, flags := convertRegexp(item)
+			pattern, flags, err := convertRegexp(item)
+			if err != nil {
+				return nil, err
+			}
 			out = append(out, expectedTextValue{
 				RegexSource:         String(pattern),
 				RegexFlags:          String(flags),
@@ -125,7 +128,7 @@ func toExpectedTextValues(
 			})
 		}
 	}
-	return out
+	return out, nil
 }
 
 func convertToInterfaceList(v interface{}) []interface{} {
@@ -33,6 +33,9 @@ func (b *browserImpl) NewContext(options ...BrowserNewContextOptions) (BrowserCo
 	if len(options) == 1 {
 		option = options[0]
 	}
+	if err := validateProxy(option.Proxy); err != nil {
+		return nil, err
+	}
 	if option.ExtraHttpHeaders != nil {
 		overrides["extraHTTPHeaders"] = serializeMapToNameAndValue(options[0].ExtraHttpHeaders)
 		options[0].ExtraHttpHeaders = nil
@@ -55,13 +58,17 @@ func (b *browserImpl) NewContext(options ...BrowserNewContextOptions) (BrowserCo
 		options[0].NoViewport = nil
 	}
 	if option.RecordHarPath != nil {
-		overrides["recordHar"] = prepareRecordHarOptions(recordHarInputOptions{
+		recordHar, err := prepareRecordHarOptions(recordHarInputOptions{
 			Path:        *options[0].RecordHarPath,
 			URL:         options[0].RecordHarURLFilter,
 			Mode:        options[0].RecordHarMode,
 			Content:     options[0].RecordHarContent,
 			OmitContent: options[0].RecordHarOmitContent,
 		})
+		if err != nil {
+			return nil, err
+		}
+		overrides["recordHar"] = recordHar
 		options[0].RecordHarPath = nil
 		options[0].RecordHarURLFilter = nil
 		options[0].RecordHarMode = nil
@@ -110,7 +117,9 @@ func (b *browserImpl) NewBrowserCDPSession() (CDPSession, error) {
 func (b *browserImpl) Contexts() []BrowserContext {
 	b.Lock()
 	defer b.Unlock()
-	return b.contexts
+	contexts := make([]BrowserContext, len(b.contexts))
+	copy(contexts, b.contexts)
+	return contexts
 }
 
 func (b *browserImpl) Close() error {
@@ -121,7 +130,7 @@ func (b *browserImpl) Close() error {
 	b.isClosedOrClosing = true
 	b.Unlock()
 	_, err := b.channel.Send("close")
-	if err != nil && !isSafeCloseError(err) {
+	if err != nil && !IsSafeCloseError(err) {
 		return fmt.Errorf("close browser failed: %w", err)
 	}
 	if b.shouldCloseConnectionOnClose {
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 	"strings"
 )
@@ -25,6 +24,7 @@ type browserContextImpl struct {
 	request           *apiRequestContextImpl
 	harRecorders      map[string]harRecordingMetadata
 	closed            chan struct{}
+	dialogPolicy      DialogPolicy
 }
 
 func (b *browserContextImpl) SetDefaultNavigationTimeout(timeout float64) {
@@ -44,10 +44,15 @@ func (b *browserContextImpl) SetDefaultTimeout(timeout float64) {
 func (b *browserContextImpl) Pages() []Page {
 	b.Lock()
 	defer b.Unlock()
-	return b.pages
+	pages := make([]Page, len(b.pages))
+	copy(pages, b.pages)
+	return pages
 }
 
 func (b *browserContextImpl) Browser() Browser {
+	if b.browser == nil {
+		return nil
+	}
 	return b.browser
 }
 func (b *browserContextImpl) Tracing() Tracing {
@@ -378,7 +383,11 @@ func (b *browserContextImpl) recordIntoHar(har string, options ...browserContext
 			harOptions.Mode = options[0].UpdateMode
 		}
 		harOptions.URL = options[0].URL
-		overrides["options"] = prepareRecordHarOptions(harOptions)
+		recordHar, err := prepareRecordHarOptions(harOptions)
+		if err != nil {
+			return err
+		}
+		overrides["options"] = recordHar
 		if options[0].Page != nil {
 			overrides["page"] = options[0].Page.(*pageImpl).channel
 		}
@@ -472,7 +481,7 @@ func (b *browserContextImpl) onRoute(route *routeImpl) {
 					return nil, err
 				}, true)
 				if err != nil {
-					log.Printf("could not update interception patterns: %v", err)
+					b.connection.logger.Errorf("could not update interception patterns: %v", err)
 				}
 			}
 			yes := <-handled
@@ -481,14 +490,17 @@ func (b *browserContextImpl) onRoute(route *routeImpl) {
 			}
 		}
 		if err := route.internalContinue(true); err != nil {
-			log.Printf("could not continue request: %v", err)
+			b.connection.logger.Errorf("could not continue request: %v", err)
 		}
 	}()
 }
 
 func (b *browserContextImpl) updateInterceptionPatterns() error {
-	patterns := prepareInterceptionPatterns(b.routes)
-	_, err := b.channel.Send("setNetworkInterceptionPatterns", map[string]interface{}{
+	patterns, err := prepareInterceptionPatterns(b.routes)
+	if err != nil {
+		return err
+	}
+	_, err = b.channel.Send("setNetworkInterceptionPatterns", map[string]interface{}{
 		"patterns": patterns,
 	})
 	return err
@@ -537,13 +549,17 @@ func (b *browserContextImpl) setOptions(options *BrowserNewContextOptions, trace
 func (b *browserContextImpl) BackgroundPages() []Page {
 	b.Lock()
 	defer b.Unlock()
-	return b.backgroundPages
+	backgroundPages := make([]Page, len(b.backgroundPages))
+	copy(backgroundPages, b.backgroundPages)
+	return backgroundPages
 }
 
 func (b *browserContextImpl) ServiceWorkers() []Worker {
 	b.Lock()
 	defer b.Unlock()
-	return b.serviceWorkers
+	serviceWorkers := make([]Worker, len(b.serviceWorkers))
+	copy(serviceWorkers, b.serviceWorkers)
+	return serviceWorkers
 }
 
 func (b *browserContextImpl) OnClose(fn func(BrowserContext)) {
@@ -554,6 +570,20 @@ func (b *browserContextImpl) OnConsole(fn func(ConsoleMessage)) {
 	b.On("console", fn)
 }
 
+func (b *browserContextImpl) SetDialogPolicy(policy DialogPolicy) {
+	b.Lock()
+	// copied, so changes to the caller's map do not race with dialogs being handled
+	b.dialogPolicy = policy.clone()
+	// The server only applies the default policy, so dialogs have to reach the client to apply a custom one. The
+	// subscription stays enabled even once the last OnDialog listener is removed.
+	delete(b.eventToSubscriptionMapping, "dialog")
+	b.Unlock()
+	b.channel.SendNoReply("updateSubscription", map[string]interface{}{
+		"event":   "dialog",
+		"enabled": true,
+	})
+}
+
 func (b *browserContextImpl) OnDialog(fn func(Dialog)) {
 	b.On("dialog", fn)
 }
@@ -586,11 +616,14 @@ func newBrowserContext(parent *channelOwner, objectType string, guid string, ini
 		bindings:        make(map[string]BindingCallFunction),
 		harRecorders:    make(map[string]harRecordingMetadata),
 		closed:          make(chan struct{}, 1),
+		dialogPolicy:    DefaultDialogPolicy(),
 	}
 	bt.createChannelOwner(bt, parent, objectType, guid, initializer)
 	if parent.objectType == "Browser" {
 		bt.browser = fromChannel(parent.channel).(*browserImpl)
+		bt.browser.Lock()
 		bt.browser.contexts = append(bt.browser.contexts, bt)
+		bt.browser.Unlock()
 	}
 	bt.tracing = fromChannel(initializer["tracing"]).(*tracingImpl)
 	bt.request = fromChannel(initializer["requestContext"]).(*apiRequestContextImpl)
@@ -631,11 +664,10 @@ func newBrowserContext(parent *channelOwner, objectType string, guid string, ini
 				// on the client side due to a possible race condition between two async calls:
 				// a) removing "dialog" listener subscription (client->server)
 				// b) actual "dialog" event (server->client)
-				if dialog.Type() == "beforeunload" {
-					_ = dialog.Accept()
-				} else {
-					_ = dialog.Dismiss()
-				}
+				bt.RLock()
+				policy := bt.dialogPolicy
+				bt.RUnlock()
+				_ = dialog.applyPolicy(policy)
 			}
 		}()
 	})
@@ -655,6 +687,9 @@ func newBrowserContext(parent *channelOwner, objectType string, guid string, ini
 		}
 		page := fromNullableChannel(ev["page"])
 		request.setResponseEndTiming(ev["responseEndTiming"].(float64))
+		if request.response != nil {
+			request.response.setFinished(request.Failure())
+		}
 		bt.Emit("requestfailed", request)
 		if page != nil {
 			page.(*pageImpl).Emit("requestfailed", request)
@@ -671,7 +706,7 @@ func newBrowserContext(parent *channelOwner, objectType string, guid string, ini
 			page.(*pageImpl).Emit("requestfinished", request)
 		}
 		if response != nil {
-			close(response.(*responseImpl).finished)
+			response.(*responseImpl).setFinished(nil)
 		}
 	})
 	bt.channel.On("response", func(ev map[string]interface{}) {
@@ -691,7 +726,7 @@ func newBrowserContext(parent *channelOwner, objectType string, guid string, ini
 		"request":         "request",
 		"response":        "response",
 		"requestfinished": "requestFinished",
-		"responsefailed":  "responseFailed",
+		"requestfailed":   "requestFailed",
 	})
 	return bt
 }
@@ -1,9 +1,14 @@
 package playwright
 
 import (
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 )
 
+var errChannelAndExecutablePath = errors.New("cannot specify both channel and executablePath")
+
 type browserTypeImpl struct {
 	channelOwner
 	playwright *Playwright
@@ -19,6 +24,14 @@ func (b *browserTypeImpl) ExecutablePath() string {
 
 func (b *browserTypeImpl) Launch(options ...BrowserTypeLaunchOptions) (Browser, error) {
 	overrides := map[string]interface{}{}
+	if len(options) == 1 && options[0].Channel != nil && options[0].ExecutablePath != nil {
+		return nil, errChannelAndExecutablePath
+	}
+	if len(options) == 1 {
+		if err := validateProxy(options[0].Proxy); err != nil {
+			return nil, err
+		}
+	}
 	if len(options) == 1 && options[0].Env != nil {
 		overrides["env"] = serializeMapToNameAndValue(options[0].Env)
 		options[0].Env = nil
@@ -39,6 +52,12 @@ func (b *browserTypeImpl) LaunchPersistentContext(userDataDir string, options ..
 	option := &BrowserNewContextOptions{}
 	var tracesDir *string = nil
 	if len(options) == 1 {
+		if options[0].Channel != nil && options[0].ExecutablePath != nil {
+			return nil, errChannelAndExecutablePath
+		}
+		if err := validateProxy(options[0].Proxy); err != nil {
+			return nil, err
+		}
 		tracesDir = options[0].TracesDir
 		err := assignStructFields(option, options[0], true)
 		if err != nil {
@@ -57,13 +76,17 @@ func (b *browserTypeImpl) LaunchPersistentContext(userDataDir string, options ..
 			options[0].NoViewport = nil
 		}
 		if options[0].RecordHarPath != nil {
-			overrides["recordHar"] = prepareRecordHarOptions(recordHarInputOptions{
+			recordHar, err := prepareRecordHarOptions(recordHarInputOptions{
 				Path:        *options[0].RecordHarPath,
 				URL:         options[0].RecordHarURLFilter,
 				Mode:        options[0].RecordHarMode,
 				Content:     options[0].RecordHarContent,
 				OmitContent: options[0].RecordHarOmitContent,
 			})
+			if err != nil {
+				return nil, err
+			}
+			overrides["recordHar"] = recordHar
 			options[0].RecordHarPath = nil
 			options[0].RecordHarURLFilter = nil
 			options[0].RecordHarMode = nil
@@ -83,6 +106,19 @@ func (b *browserTypeImpl) Connect(wsEndpoint string, options ...BrowserTypeConne
 	overrides := map[string]interface{}{
 		"wsEndpoint": wsEndpoint,
 	}
+	var keepAliveInterval time.Duration
+	keepAliveTimeout := time.Duration(defaultTimeout) * time.Millisecond
+	if len(options) == 1 {
+		option := options[0]
+		if option.KeepAliveInterval != nil {
+			keepAliveInterval = time.Duration(*option.KeepAliveInterval * float64(time.Millisecond))
+		}
+		if option.KeepAliveTimeout != nil {
+			keepAliveTimeout = time.Duration(*option.KeepAliveTimeout * float64(time.Millisecond))
+		}
+		option.KeepAliveInterval, option.KeepAliveTimeout = nil, nil
+		options = []BrowserTypeConnectOptions{option}
+	}
 	localUtils := b.connection.LocalUtils()
 	pipe, err := localUtils.channel.SendReturnAsDict("connect", overrides, options)
 	if err != nil {
@@ -91,17 +127,27 @@ func (b *browserTypeImpl) Connect(wsEndpoint string, options ...BrowserTypeConne
 	jsonPipe := fromChannel(pipe.(map[string]interface{})["pipe"]).(*jsonPipe)
 	connection := newConnection(jsonPipe.Close, localUtils)
 	connection.isRemote = true
+	connection.logger = b.connection.logger
+	connection.asyncEvents = b.connection.asyncEvents
+	connection.eventReplayBufferSize = b.connection.eventReplayBufferSize
 	var browser *browserImpl
+	var closeOnce sync.Once
 	pipeClosed := func() {
-		for _, context := range browser.Contexts() {
-			pages := context.Pages()
-			for _, page := range pages {
-				page.(*pageImpl).onClose()
+		closeOnce.Do(func() {
+			if browser == nil {
+				connection.cleanup()
+				return
 			}
-			context.(*browserContextImpl).onClose()
-		}
-		browser.onClose()
-		connection.cleanup()
+			for _, context := range browser.Contexts() {
+				pages := context.Pages()
+				for _, page := range pages {
+					page.(*pageImpl).onClose()
+				}
+				context.(*browserContextImpl).onClose()
+			}
+			browser.onClose()
+			connection.cleanup()
+		})
 	}
 	jsonPipe.On("closed", pipeClosed)
 	connection.onmessage = func(message map[string]interface{}) error {
@@ -112,14 +158,60 @@ func (b *browserTypeImpl) Connect(wsEndpoint string, options ...BrowserTypeConne
 		return nil
 	}
 	jsonPipe.On("message", connection.Dispatch)
-	playwright := connection.Start()
+	playwright, err := connection.Start()
+	if err != nil {
+		// Release the pipe on the driver side, as nothing will use this connection.
+		_ = jsonPipe.Close()
+		connection.cleanup()
+		return nil, err
+	}
 	playwright.setSelectors(b.playwright.Selectors)
 	browser = fromChannel(playwright.initializer["preLaunchedBrowser"]).(*browserImpl)
 	browser.shouldCloseConnectionOnClose = true
 	b.didLaunchBrowser(browser)
+	if keepAliveInterval > 0 {
+		go keepAlive(connection, browser, keepAliveInterval, keepAliveTimeout, func() {
+			connection.cleanupWithError(fmt.Errorf("remote connection did not answer within %s", keepAliveTimeout))
+			pipeClosed()
+			_ = jsonPipe.Close()
+		})
+	}
 	return browser, nil
 }
 
+// keepAlive round-trips a cheap call to the remote browser every interval and calls onDead when one is not
+// answered within timeout, so a connection that died silently, e.g. behind a load balancer, does not hang forever.
+func keepAlive(connection *connection, browser *browserImpl, interval, timeout time.Duration, onDead func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-connection.abort:
+			return
+		case <-ticker.C:
+		}
+		answered := make(chan struct{})
+		go func() {
+			defer close(answered)
+			// The 1.37 protocol has no ping. Despite its name, defaultUserAgentForTest is served by every browser
+			// type in release builds and has no side effects, so it is the cheapest call that reaches the browser.
+			_, _ = browser.channel.SendReturnAsDict("defaultUserAgentForTest")
+		}()
+		deadline := time.NewTimer(timeout)
+		select {
+		case <-answered:
+			deadline.Stop()
+		case <-connection.abort:
+			deadline.Stop()
+			return
+		case <-deadline.C:
+			connection.logger.Warnf("remote connection did not answer within %s, closing it", timeout)
+			onDead()
+			return
+		}
+	}
+}
+
 func (b *browserTypeImpl) ConnectOverCDP(endpointURL string, options ...BrowserTypeConnectOverCDPOptions) (Browser, error) {
 	overrides := map[string]interface{}{
 		"endpointURL": endpointURL,
@@ -4,12 +4,44 @@ type cdpSessionImpl struct {
 	channelOwner
 }
 
+// NetworkConditions describes the network to emulate with [Page.EmulateNetworkConditions].
+type NetworkConditions struct {
+	// Whether to emulate a disconnected network.
+	Offline bool
+	// Minimum latency from request sent to response headers received, in milliseconds.
+	Latency float64
+	// Maximal aggregated download throughput in bytes per second. -1 disables download throttling.
+	DownloadThroughput float64
+	// Maximal aggregated upload throughput in bytes per second. -1 disables upload throttling.
+	UploadThroughput float64
+}
+
+var (
+	// NoNetworkThrottling disables any previously emulated network conditions.
+	NoNetworkThrottling = NetworkConditions{
+		DownloadThroughput: -1,
+		UploadThroughput:   -1,
+	}
+	// Slow3G emulates a slow 3G connection, matching the Chrome DevTools preset.
+	Slow3G = NetworkConditions{
+		Latency:            400 * 5,
+		DownloadThroughput: 500 * 1000 / 8 * 0.8,
+		UploadThroughput:   500 * 1000 / 8 * 0.8,
+	}
+	// Fast3G emulates a fast 3G connection, matching the Chrome DevTools preset.
+	Fast3G = NetworkConditions{
+		Latency:            150 * 3.75,
+		DownloadThroughput: 1.6 * 1000 * 1000 / 8 * 0.9,
+		UploadThroughput:   750 * 1000 / 8 * 0.9,
+	}
+)
+
 func (c *cdpSessionImpl) Detach() error {
 	_, err := c.channel.Send("detach")
 	return err
 }
 
-func (c *cdpSessionImpl) Send(method string, params map[string]interface{}) (interface{}, error) {
+func (c *cdpSessionImpl) Send(method string, params map[string]interface{}) (map[string]interface{}, error) {
 	result, err := c.channel.Send("send", map[string]interface{}{
 		"method": method,
 		"params": params,
@@ -17,12 +49,18 @@ func (c *cdpSessionImpl) Send(method string, params map[string]interface{}) (int
 	if err != nil {
 		return nil, err
 	}
-
-	return result, err
+	if result == nil {
+		return map[string]interface{}{}, nil
+	}
+	return result.(map[string]interface{}), nil
 }
 
 func (c *cdpSessionImpl) onEvent(params map[string]interface{}) {
-	c.Emit(params["method"].(string), params["params"])
+	payload, ok := params["params"].(map[string]interface{})
+	if !ok {
+		payload = map[string]interface{}{}
+	}
+	c.Emit(params["method"].(string), payload)
 }
 
 func newCDPSession(parent *channelOwner, objectType string, guid string, initializer map[string]interface{}) *cdpSessionImpl {
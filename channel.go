@@ -1,7 +1,7 @@
 package playwright
 
 import (
-	"log"
+	"errors"
 	"reflect"
 )
 
@@ -13,26 +13,35 @@ type channel struct {
 }
 
 func (c *channel) Send(method string, options ...interface{}) (interface{}, error) {
-	return c.connection.WrapAPICall(func() (interface{}, error) {
-		return c.innerSend(method, false, options...)
-	}, false)
+	return c.innerSend(method, false, serializeCallStack(false), options...)
 }
 
 func (c *channel) SendReturnAsDict(method string, options ...interface{}) (interface{}, error) {
-	return c.connection.WrapAPICall(func() (interface{}, error) {
-		return c.innerSend(method, true, options...)
-	}, true)
+	return c.innerSend(method, true, serializeCallStack(true), options...)
 }
 
-func (c *channel) innerSend(method string, returnAsDict bool, options ...interface{}) (interface{}, error) {
+func (c *channel) innerSend(method string, returnAsDict bool, apiZone parsedStackTrace, options ...interface{}) (interface{}, error) {
+	if owner, ok := c.object.(detachable); ok {
+		if err := owner.detachedError(nil); err != nil {
+			return nil, err
+		}
+	}
 	params := transformOptions(options...)
-	callback, err := c.connection.sendMessageToServer(c.guid, method, params, false)
+	if err := validateKeyboardModifiers(params); err != nil {
+		return nil, err
+	}
+	callback, err := c.connection.sendMessageToServer(c.guid, method, params, false, apiZone)
 	if err != nil {
 		return nil, err
 	}
 	result, err := callback.GetResult()
 	if err != nil {
-		return nil, err
+		if owner, ok := c.object.(detachable); ok {
+			if detachedErr := owner.detachedError(err); detachedErr != nil {
+				return nil, detachedErr
+			}
+		}
+		return nil, c.withCloseReason(err)
 	}
 	if result == nil {
 		return nil, nil
@@ -52,13 +61,33 @@ func (c *channel) innerSend(method string, returnAsDict bool, options ...interfa
 	return result, nil
 }
 
+// closeReasoner is implemented by objects whose operations report the reason passed when they were closed.
+type closeReasoner interface {
+	getCloseReason() string
+}
+
+// detachable is implemented by objects that stop working once they are detached, like frames. detachedError returns
+// nil while the object is attached, and otherwise an error wrapping cause, which may be nil.
+type detachable interface {
+	detachedError(cause error) error
+}
+
+func (c *channel) withCloseReason(err error) error {
+	var closed *TargetClosedError
+	if !errors.As(err, &closed) {
+		return err
+	}
+	if owner, ok := c.object.(closeReasoner); ok && closed.Reason == "" {
+		closed.Reason = owner.getCloseReason()
+	}
+	return err
+}
+
 func (c *channel) SendNoReply(method string, options ...interface{}) {
 	params := transformOptions(options...)
-	_, err := c.connection.WrapAPICall(func() (interface{}, error) {
-		return c.connection.sendMessageToServer(c.guid, method, params, true)
-	}, false)
+	_, err := c.connection.sendMessageToServer(c.guid, method, params, true, serializeCallStack(false))
 	if err != nil {
-		log.Printf("SendNoReply failed: %v", err)
+		c.connection.logger.Errorf("SendNoReply failed: %v", err)
 	}
 }
 
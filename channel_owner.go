@@ -89,7 +89,14 @@ func (c *channelOwner) createChannelOwner(self interface{}, parent *channelOwner
 	c.channel = newChannel(c.connection, guid)
 	c.channel.object = self
 	c.eventToSubscriptionMapping = map[string]string{}
-	c.initEventEmitter()
+	if c.connection != nil && c.connection.asyncEvents {
+		c.initQueuedEventEmitter()
+	} else {
+		c.initEventEmitter()
+	}
+	if c.connection != nil {
+		c.replaySize = c.connection.eventReplayBufferSize
+	}
 }
 
 type rootChannelOwner struct {
@@ -3,9 +3,9 @@ package playwright
 import (
 	"errors"
 	"fmt"
-	"log"
 	"reflect"
 	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,6 +18,9 @@ import (
 var (
 	pkgSourcePathPattern = regexp.MustCompile(`.+[\\/]playwright-go[\\/][^\\/]+\.go`)
 	apiNameTransform     = regexp.MustCompile(`(?U)\(\*(.+)(Impl)?\)`)
+	// internalAPICallFunction and publicAPICallFunction are the frames [connection.WrapAPICall] leaves on the stack.
+	internalAPICallFunction = runtime.FuncForPC(reflect.ValueOf(internalAPICall).Pointer()).Name()
+	publicAPICallFunction   = runtime.FuncForPC(reflect.ValueOf(publicAPICall).Pointer()).Name()
 )
 
 type result struct {
@@ -26,7 +29,6 @@ type result struct {
 }
 
 type connection struct {
-	apiZone      sync.Map
 	objects      map[string]*channelOwner
 	lastID       int
 	lastIDLock   sync.Mutex
@@ -37,21 +39,99 @@ type connection struct {
 	onmessage    func(map[string]interface{}) error
 	isRemote     bool
 	localUtils   *localUtilsImpl
+	selectors    *selectorsImpl
 	tracingCount atomic.Int32
 	abort        chan struct{}
+	closeErr     error
+	closeErrLock sync.Mutex
+	logger       Logger
+	stats        connectionStats
+	startTimeout time.Duration
+	asyncEvents  bool
+	// eventReplayBufferSize is the number of events per name objects keep for OnWithReplay.
+	eventReplayBufferSize int
 }
 
-func (c *connection) Start() *Playwright {
-	playwright := make(chan *Playwright, 1)
+// ConnectionStats is a snapshot of the traffic between the library and the driver.
+type ConnectionStats struct {
+	// PendingCalls is the number of protocol calls that are still waiting for a response.
+	PendingCalls int
+	// MessagesSent is the number of messages sent to the driver.
+	MessagesSent int64
+	// MessagesReceived is the number of messages received from the driver, both responses and events.
+	MessagesReceived int64
+	// BytesSent is the number of bytes written to the driver pipe. It is not tracked for remote connections.
+	BytesSent int64
+	// BytesReceived is the number of bytes read from the driver pipe. It is not tracked for remote connections.
+	BytesReceived int64
+}
+
+type connectionStats struct {
+	messagesSent     atomic.Int64
+	messagesReceived atomic.Int64
+	bytesSent        atomic.Int64
+	bytesReceived    atomic.Int64
+}
+
+// Stats returns the current traffic counters of the connection.
+func (c *connection) Stats() ConnectionStats {
+	pending := 0
+	c.callbacks.Range(func(_, _ interface{}) bool {
+		pending++
+		return true
+	})
+	return ConnectionStats{
+		PendingCalls:     pending,
+		MessagesSent:     c.stats.messagesSent.Load(),
+		MessagesReceived: c.stats.messagesReceived.Load(),
+		BytesSent:        c.stats.bytesSent.Load(),
+		BytesReceived:    c.stats.bytesReceived.Load(),
+	}
+}
+
+// Start waits for the driver to create the root Playwright object. Initialization failures are returned rather
+// than terminating the process, so callers can retry or report them. Start also returns once the connection is
+// closed, or when startTimeout is set and has elapsed.
+func (c *connection) Start() (*Playwright, error) {
+	type startResult struct {
+		playwright *Playwright
+		err        error
+	}
+	started := make(chan startResult, 1)
 	go func() {
 		pw, err := c.rootObject.initialize()
 		if err != nil {
-			log.Fatal(err)
-			return
+			// A driver that died while starting usually fails the first write before its exit is noticed, so give
+			// the connection a moment to record why it was lost.
+			select {
+			case <-c.abort:
+			case <-time.After(time.Second):
+			}
+			if closeErr := c.closeError(); closeErr != nil {
+				err = closeErr
+			}
+			err = fmt.Errorf("could not initialize playwright: %w", err)
 		}
-		playwright <- pw
+		started <- startResult{pw, err}
 	}()
-	return <-playwright
+	var timeout <-chan time.Time
+	if c.startTimeout > 0 {
+		timer := time.NewTimer(c.startTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case result := <-started:
+		return result.playwright, result.err
+	case <-c.abort:
+		err := c.closeError()
+		if err == nil {
+			err = errors.New("connection closed")
+		}
+		return nil, fmt.Errorf("could not initialize playwright: %w", err)
+	case <-timeout:
+		return nil, fmt.Errorf("could not initialize playwright: timed out after %s", c.startTimeout)
+	}
 }
 
 func (c *connection) Stop() error {
@@ -64,20 +144,40 @@ func (c *connection) Stop() error {
 }
 
 func (c *connection) cleanup() {
-	if c.afterClose != nil {
-		c.afterClose()
-	}
+	c.cleanupWithError(nil)
+}
+
+// cleanupWithError tears the connection down once. A non-nil err records why the connection was lost.
+func (c *connection) cleanupWithError(err error) {
+	c.closeErrLock.Lock()
 	select {
 	case <-c.abort:
+		c.closeErrLock.Unlock()
+		return
 	default:
+		c.closeErr = err
 		close(c.abort)
 	}
+	c.closeErrLock.Unlock()
+	if c.afterClose != nil {
+		c.afterClose()
+	}
+}
+
+func (c *connection) closeError() error {
+	c.closeErrLock.Lock()
+	defer c.closeErrLock.Unlock()
+	return c.closeErr
 }
 
 func (c *connection) Dispatch(msg *message) {
+	c.stats.messagesReceived.Add(1)
 	method := msg.Method
 	if msg.ID != 0 {
-		cb, _ := c.callbacks.LoadAndDelete(msg.ID)
+		cb, ok := c.callbacks.LoadAndDelete(msg.ID)
+		if !ok {
+			return
+		}
 		if cb.(*protocolCallback).noReply {
 			return
 		}
@@ -125,17 +225,36 @@ func (c *connection) LocalUtils() *localUtilsImpl {
 	return c.localUtils
 }
 
+// testIdAttributeName returns the attribute configured on the Selectors instance this connection is registered with.
+func (c *connection) testIdAttributeName() string {
+	if c.selectors == nil {
+		return defaultTestIdAttributeName
+	}
+	return c.selectors.getTestIdAttributeName()
+}
+
 func (c *connection) createRemoteObject(parent *channelOwner, objectType string, guid string, initializer interface{}) interface{} {
 	initializer = c.replaceGuidsWithChannels(initializer)
 	result := createObjectFactory(parent, objectType, guid, initializer.(map[string]interface{}))
 	return result
 }
 
+// WrapAPICall runs cb as a single API call, so every message cb sends is reported with the given isInternal instead of
+// its own. The outermost call on the stack wins; serializeCallStack finds it by its frame.
 func (c *connection) WrapAPICall(cb func() (interface{}, error), isInternal bool) (interface{}, error) {
-	if _, ok := c.apiZone.Load("apiZone"); ok {
-		return cb()
+	if isInternal {
+		return internalAPICall(cb)
 	}
-	c.apiZone.Store("apiZone", serializeCallStack(isInternal))
+	return publicAPICall(cb)
+}
+
+//go:noinline
+func internalAPICall(cb func() (interface{}, error)) (interface{}, error) {
+	return cb()
+}
+
+//go:noinline
+func publicAPICall(cb func() (interface{}, error)) (interface{}, error) {
 	return cb()
 }
 
@@ -193,7 +312,7 @@ func (c *connection) replaceGuidsWithChannels(payload interface{}) interface{} {
 	return payload
 }
 
-func (c *connection) sendMessageToServer(guid string, method string, params interface{}, noReply bool) (*protocolCallback, error) {
+func (c *connection) sendMessageToServer(guid string, method string, params interface{}, noReply bool, apiZone parsedStackTrace) (*protocolCallback, error) {
 	c.lastIDLock.Lock()
 	c.lastID++
 	id := c.lastID
@@ -202,14 +321,12 @@ func (c *connection) sendMessageToServer(guid string, method string, params inte
 		metadata = make(map[string]interface{}, 0)
 		stack    = make([]map[string]interface{}, 0)
 	)
-	apiZone, ok := c.apiZone.LoadAndDelete("apiZone")
-	if ok {
-		for k, v := range apiZone.(parsedStackTrace).metadata {
-			metadata[k] = v
-		}
-		stack = append(stack, apiZone.(parsedStackTrace).frames...)
+	for k, v := range apiZone.metadata {
+		metadata[k] = v
 	}
-	metadata["wallTime"] = time.Now().Nanosecond()
+	stack = append(stack, apiZone.frames...)
+	// The protocol expects milliseconds since the epoch, like Date.now() in JavaScript.
+	metadata["wallTime"] = time.Now().UnixMilli()
 	message := map[string]interface{}{
 		"id":       id,
 		"guid":     guid,
@@ -217,10 +334,20 @@ func (c *connection) sendMessageToServer(guid string, method string, params inte
 		"params":   c.replaceChannelsWithGuids(params),
 		"metadata": metadata,
 	}
-	cb, _ := c.callbacks.LoadOrStore(id, newProtocolCallback(noReply, c.abort))
+	callback := newProtocolCallback(noReply, c.abort)
+	for _, frame := range stack {
+		callback.callStack = append(callback.callStack, StackFrame{
+			File:     frame["file"].(string),
+			Line:     frame["line"].(int),
+			Function: frame["function"].(string),
+		})
+	}
+	cb, _ := c.callbacks.LoadOrStore(id, callback)
 	if err := c.onmessage(message); err != nil {
+		c.callbacks.Delete(id)
 		return nil, fmt.Errorf("could not send message: %w", err)
 	}
+	c.stats.messagesSent.Add(1)
 
 	if c.tracingCount.Load() > 0 && len(stack) > 0 && guid != "localUtils" {
 		c.LocalUtils().AddStackToTracingNoReply(id, stack)
@@ -241,6 +368,8 @@ type parsedStackTrace struct {
 	metadata map[string]interface{}
 }
 
+// serializeCallStack describes the calling API for a message. isInternal is used unless the message is sent from
+// within [connection.WrapAPICall].
 func serializeCallStack(isInternal bool) parsedStackTrace {
 	st := stack.Trace().TrimRuntime()
 
@@ -249,6 +378,12 @@ func serializeCallStack(isInternal bool) parsedStackTrace {
 		if pkgSourcePathPattern.MatchString(s.Frame().File) {
 			lastInternalIndex = i
 		}
+		switch s.Frame().Function {
+		case internalAPICallFunction:
+			isInternal = true
+		case publicAPICallFunction:
+			isInternal = false
+		}
 	}
 	apiName := ""
 	if len(st) > 0 {
@@ -300,6 +435,7 @@ func newConnection(onClose func() error, localUtils ...*localUtilsImpl) *connect
 		objects:  make(map[string]*channelOwner),
 		onClose:  onClose,
 		isRemote: false,
+		logger:   newLeveledLogger(nil, LogLevelDebug),
 	}
 	if len(localUtils) > 0 {
 		connection.localUtils = localUtils[0]
@@ -320,9 +456,10 @@ func fromNullableChannel(v interface{}) interface{} {
 }
 
 type protocolCallback struct {
-	Callback chan result
-	noReply  bool
-	abort    <-chan struct{}
+	Callback  chan result
+	noReply   bool
+	abort     <-chan struct{}
+	callStack []StackFrame
 }
 
 func (pc *protocolCallback) SetResult(r result) {
@@ -342,6 +479,10 @@ func (pc *protocolCallback) GetResult() (interface{}, error) {
 	}
 	select {
 	case result := <-pc.Callback:
+		var pwErr *Error
+		if errors.As(result.Error, &pwErr) {
+			pwErr.callStack = pc.callStack
+		}
 		return result.Data, result.Error
 	case <-pc.abort:
 		return nil, errors.New("Connection closed")
@@ -0,0 +1,119 @@
+package playwright
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestConnectionStats(t *testing.T) {
+	conn := newConnection(func() error { return nil })
+	var out bytes.Buffer
+	transport := newPipeTransport(nopWriteCloser{&out}, io.NopCloser(&bytes.Buffer{}), conn.logger)
+	transport.stats = &conn.stats
+	conn.onmessage = transport.Send
+
+	callback, err := conn.sendMessageToServer("guid", "method", map[string]interface{}{}, false, parsedStackTrace{})
+	require.NoError(t, err)
+	stats := conn.Stats()
+	require.Equal(t, 1, stats.PendingCalls)
+	require.Equal(t, int64(1), stats.MessagesSent)
+	require.Equal(t, int64(out.Len()), stats.BytesSent)
+
+	go conn.Dispatch(&message{ID: 1, Result: map[string]interface{}{}})
+	_, err = callback.GetResult()
+	require.NoError(t, err)
+	stats = conn.Stats()
+	require.Equal(t, 0, stats.PendingCalls)
+	require.Equal(t, int64(1), stats.MessagesReceived)
+
+	// Responses to unknown calls are ignored instead of panicking.
+	conn.Dispatch(&message{ID: 42})
+	require.Equal(t, int64(2), conn.Stats().MessagesReceived)
+}
+
+func TestConnectionWallTimeIsEpochMillis(t *testing.T) {
+	conn := newConnection(func() error { return nil })
+	var sent map[string]interface{}
+	conn.onmessage = func(msg map[string]interface{}) error {
+		sent = msg
+		return nil
+	}
+	before := time.Now().UnixMilli()
+	_, err := conn.sendMessageToServer("guid", "method", map[string]interface{}{}, true, parsedStackTrace{})
+	require.NoError(t, err)
+	after := time.Now().UnixMilli()
+
+	wallTime, ok := sent["metadata"].(map[string]interface{})["wallTime"].(int64)
+	require.True(t, ok)
+	require.GreaterOrEqual(t, wallTime, before)
+	require.LessOrEqual(t, wallTime, after)
+}
+
+func TestConnectionWrapAPICallDecidesIsInternal(t *testing.T) {
+	conn := newConnection(func() error { return nil })
+	var lock sync.Mutex
+	internalByMethod := map[string][]interface{}{}
+	conn.onmessage = func(msg map[string]interface{}) error {
+		lock.Lock()
+		defer lock.Unlock()
+		method := msg["method"].(string)
+		internalByMethod[method] = append(internalByMethod[method], msg["metadata"].(map[string]interface{})["isInternal"])
+		return nil
+	}
+	const calls = 50
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		for _, isInternal := range []bool{true, false} {
+			wg.Add(1)
+			go func(isInternal bool) {
+				defer wg.Done()
+				method := "public"
+				if isInternal {
+					method = "internal"
+				}
+				_, err := conn.WrapAPICall(func() (interface{}, error) {
+					// Both messages belong to the outer call, whatever they were sent as.
+					for j := 0; j < 2; j++ {
+						if _, err := conn.sendMessageToServer("guid", method, nil, true, serializeCallStack(!isInternal)); err != nil {
+							return nil, err
+						}
+					}
+					return nil, nil
+				}, isInternal)
+				require.NoError(t, err)
+			}(isInternal)
+		}
+	}
+	wg.Wait()
+	require.Len(t, internalByMethod["internal"], 2*calls)
+	require.Len(t, internalByMethod["public"], 2*calls)
+	for _, isInternal := range internalByMethod["internal"] {
+		require.Equal(t, true, isInternal)
+	}
+	for _, isInternal := range internalByMethod["public"] {
+		require.Equal(t, false, isInternal)
+	}
+}
+
+func TestConnectionWrapAPICallOutermostCallWins(t *testing.T) {
+	conn := newConnection(func() error { return nil })
+	require.True(t, serializeCallStack(true).metadata["isInternal"].(bool))
+	_, err := conn.WrapAPICall(func() (interface{}, error) {
+		return conn.WrapAPICall(func() (interface{}, error) {
+			require.False(t, serializeCallStack(true).metadata["isInternal"].(bool))
+			return nil, nil
+		}, true)
+	}, false)
+	require.NoError(t, err)
+}
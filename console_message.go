@@ -1,5 +1,7 @@
 package playwright
 
+import "sync"
+
 type consoleMessageImpl struct {
 	channelOwner
 	page Page
@@ -21,7 +23,7 @@ func (c *consoleMessageImpl) Args() []JSHandle {
 	args := c.initializer["args"].([]interface{})
 	out := []JSHandle{}
 	for idx := range args {
-		out = append(out, fromChannel(args[idx]).(*jsHandleImpl))
+		out = append(out, fromChannel(args[idx]).(JSHandle))
 	}
 	return out
 }
@@ -48,3 +50,63 @@ func newConsoleMessage(parent *channelOwner, objectType string, guid string, ini
 	}
 	return bt
 }
+
+// ConsoleMessageCollector records the console messages of a [Page] or [BrowserContext], see
+// [CollectConsoleMessages]. It is safe to use from multiple goroutines.
+type ConsoleMessageCollector struct {
+	mu       sync.Mutex
+	types    map[string]bool
+	messages []ConsoleMessage
+	stopped  bool
+}
+
+// CollectConsoleMessages starts recording console messages emitted by source, usually a [Page] or a
+// [BrowserContext]. When types are given, e.g. `error` or `warning`, only messages of those [ConsoleMessage.Type]s
+// are recorded. A typical use is asserting that a test didn't log any errors:
+//
+//	errors := playwright.CollectConsoleMessages(page, "error")
+//	// ... drive the page ...
+//	if msgs := errors.Messages(); len(msgs) > 0 { ... }
+func CollectConsoleMessages(source interface{ OnConsole(fn func(ConsoleMessage)) }, types ...string) *ConsoleMessageCollector {
+	c := &ConsoleMessageCollector{
+		types:    make(map[string]bool, len(types)),
+		messages: make([]ConsoleMessage, 0),
+	}
+	for _, typ := range types {
+		c.types[typ] = true
+	}
+	source.OnConsole(c.onConsole)
+	return c
+}
+
+func (c *ConsoleMessageCollector) onConsole(message ConsoleMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped || (len(c.types) > 0 && !c.types[message.Type()]) {
+		return
+	}
+	c.messages = append(c.messages, message)
+}
+
+// Messages returns a copy of the messages recorded so far, in the order they were emitted.
+func (c *ConsoleMessageCollector) Messages() []ConsoleMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ConsoleMessage(nil), c.messages...)
+}
+
+// Drain returns the messages recorded so far and clears them, so the next call only returns newer messages.
+func (c *ConsoleMessageCollector) Drain() []ConsoleMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	messages := c.messages
+	c.messages = make([]ConsoleMessage, 0)
+	return messages
+}
+
+// Stop stops recording. Messages recorded before Stop are kept.
+func (c *ConsoleMessageCollector) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+}
@@ -0,0 +1,422 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const playwrightEvaluationScriptURL = "__playwright_evaluation_script__"
+
+// Coverage gathers information about parts of JavaScript and CSS that were used by the page.
+// **NOTE** Coverage APIs are only supported on Chromium-based browsers.
+type Coverage interface {
+	// Starts gathering which parts of the page stylesheets are used.
+	StartCSSCoverage(options ...CoverageStartCSSCoverageOptions) error
+
+	// Starts gathering precise, block-level JavaScript coverage.
+	StartJSCoverage(options ...CoverageStartJSCoverageOptions) error
+
+	// Returns coverage for all stylesheets added since coverage was started. Stylesheets without a URL are not reported.
+	StopCSSCoverage() ([]CSSCoverageEntry, error)
+
+	// Returns V8 coverage for all scripts parsed since coverage was started.
+	StopJSCoverage() ([]JSCoverageEntry, error)
+}
+
+// CoverageStartCSSCoverageOptions are the options of [Coverage.StartCSSCoverage].
+type CoverageStartCSSCoverageOptions struct {
+	// Whether to reset coverage on every navigation. Defaults to `true`.
+	ResetOnNavigation *bool `json:"resetOnNavigation"`
+}
+
+// CoverageStartJSCoverageOptions are the options of [Coverage.StartJSCoverage].
+type CoverageStartJSCoverageOptions struct {
+	// Whether anonymous scripts generated by the page should be reported. Defaults to `false`.
+	ReportAnonymousScripts *bool `json:"reportAnonymousScripts"`
+	// Whether to reset coverage on every navigation. Defaults to `true`.
+	ResetOnNavigation *bool `json:"resetOnNavigation"`
+}
+
+// CoverageRange is a range of a script or stylesheet reported by [Coverage].
+type CoverageRange struct {
+	// Start offset of the range in characters, inclusive.
+	StartOffset int `json:"startOffset"`
+	// End offset of the range in characters, exclusive.
+	EndOffset int `json:"endOffset"`
+	// Number of times the range was executed. Always `0` for CSS coverage.
+	Count int `json:"count"`
+}
+
+// JSCoverageFunction is the coverage of a single function of a [JSCoverageEntry].
+type JSCoverageFunction struct {
+	// Name of the function, empty for anonymous functions.
+	FunctionName string `json:"functionName"`
+	// Whether the ranges describe block coverage rather than function coverage only.
+	IsBlockCoverage bool `json:"isBlockCoverage"`
+	// Executed ranges of the function, the first one spanning the whole function.
+	Ranges []CoverageRange `json:"ranges"`
+}
+
+// JSCoverageEntry is the coverage of a script, returned by [Coverage.StopJSCoverage].
+type JSCoverageEntry struct {
+	// URL of the script.
+	URL string `json:"url"`
+	// V8 identifier of the script.
+	ScriptID string `json:"scriptId"`
+	// Source text of the script.
+	Source string `json:"source"`
+	// Per-function V8 coverage, in the format consumed by tools like `v8-to-istanbul`.
+	Functions []JSCoverageFunction `json:"functions"`
+}
+
+// CSSCoverageEntry is the coverage of a stylesheet, returned by [Coverage.StopCSSCoverage].
+type CSSCoverageEntry struct {
+	// URL of the stylesheet.
+	URL string `json:"url"`
+	// Text of the stylesheet.
+	Text string `json:"text"`
+	// Merged, non-overlapping ranges of the stylesheet text that were used.
+	Ranges []CoverageRange `json:"ranges"`
+}
+
+type coverageImpl struct {
+	page *pageImpl
+
+	sync.Mutex
+	listening bool
+
+	jsEnabled              bool
+	jsResetOnNavigation    bool
+	reportAnonymousScripts bool
+	scriptURLs             map[string]string
+	// scriptSources is filled as scripts are parsed, since the source of a script from a previous page or one that was
+	// garbage collected can no longer be fetched when coverage stops.
+	scriptSources        map[string]string
+	pendingSources       sync.WaitGroup
+	jsSession            CDPSession
+	cssEnabled           bool
+	cssResetOnNavigation bool
+	stylesheetURLs       map[string]string
+}
+
+func (c *coverageImpl) StartJSCoverage(options ...CoverageStartJSCoverageOptions) error {
+	session, err := c.session()
+	if err != nil {
+		return err
+	}
+	c.Lock()
+	if c.jsEnabled {
+		c.Unlock()
+		return errors.New("JSCoverage is already enabled")
+	}
+	c.jsEnabled = true
+	c.jsResetOnNavigation = true
+	c.reportAnonymousScripts = false
+	if len(options) == 1 {
+		if options[0].ResetOnNavigation != nil {
+			c.jsResetOnNavigation = *options[0].ResetOnNavigation
+		}
+		if options[0].ReportAnonymousScripts != nil {
+			c.reportAnonymousScripts = *options[0].ReportAnonymousScripts
+		}
+	}
+	c.scriptURLs = make(map[string]string)
+	c.scriptSources = make(map[string]string)
+	c.jsSession = session
+	c.Unlock()
+
+	for _, call := range []struct {
+		method string
+		params map[string]interface{}
+	}{
+		{"Runtime.enable", nil},
+		{"Profiler.enable", nil},
+		{"Profiler.startPreciseCoverage", map[string]interface{}{"callCount": true, "detailed": true}},
+		{"Debugger.enable", nil},
+		{"Debugger.setSkipAllPauses", map[string]interface{}{"skip": true}},
+	} {
+		if _, err := session.Send(call.method, call.params); err != nil {
+			c.Lock()
+			c.jsEnabled = false
+			c.Unlock()
+			return fmt.Errorf("could not start JS coverage: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *coverageImpl) StopJSCoverage() ([]JSCoverageEntry, error) {
+	c.Lock()
+	if !c.jsEnabled {
+		c.Unlock()
+		return nil, errors.New("JSCoverage is not enabled")
+	}
+	c.jsEnabled = false
+	c.Unlock()
+	// no more sources are requested once coverage is disabled
+	c.pendingSources.Wait()
+	c.Lock()
+	scriptURLs, scriptSources := c.scriptURLs, c.scriptSources
+	c.scriptURLs, c.scriptSources = nil, nil
+	c.Unlock()
+
+	session, err := c.session()
+	if err != nil {
+		return nil, err
+	}
+	result, err := session.Send("Profiler.takePreciseCoverage", nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not take JS coverage: %w", err)
+	}
+	entries := make([]JSCoverageEntry, 0)
+	scripts, _ := result["result"].([]interface{})
+	for _, s := range scripts {
+		script := s.(map[string]interface{})
+		scriptID := script["scriptId"].(string)
+		url, ok := scriptURLs[scriptID]
+		if !ok {
+			continue
+		}
+		// scripts whose source could not be fetched are skipped rather than failing the whole report
+		source, ok := scriptSources[scriptID]
+		if !ok {
+			continue
+		}
+		entry := JSCoverageEntry{
+			URL:       url,
+			ScriptID:  scriptID,
+			Source:    source,
+			Functions: make([]JSCoverageFunction, 0),
+		}
+		functions, _ := script["functions"].([]interface{})
+		for _, f := range functions {
+			function := f.(map[string]interface{})
+			fn := JSCoverageFunction{
+				FunctionName:    function["functionName"].(string),
+				IsBlockCoverage: function["isBlockCoverage"].(bool),
+				Ranges:          make([]CoverageRange, 0),
+			}
+			ranges, _ := function["ranges"].([]interface{})
+			for _, r := range ranges {
+				fn.Ranges = append(fn.Ranges, parseCoverageRange(r.(map[string]interface{})))
+			}
+			entry.Functions = append(entry.Functions, fn)
+		}
+		entries = append(entries, entry)
+	}
+	for _, method := range []string{"Profiler.stopPreciseCoverage", "Profiler.disable", "Debugger.disable"} {
+		if _, err := session.Send(method, nil); err != nil {
+			return nil, fmt.Errorf("could not stop JS coverage: %w", err)
+		}
+	}
+	return entries, nil
+}
+
+func (c *coverageImpl) StartCSSCoverage(options ...CoverageStartCSSCoverageOptions) error {
+	session, err := c.session()
+	if err != nil {
+		return err
+	}
+	c.Lock()
+	if c.cssEnabled {
+		c.Unlock()
+		return errors.New("CSSCoverage is already enabled")
+	}
+	c.cssEnabled = true
+	c.cssResetOnNavigation = true
+	if len(options) == 1 && options[0].ResetOnNavigation != nil {
+		c.cssResetOnNavigation = *options[0].ResetOnNavigation
+	}
+	c.stylesheetURLs = make(map[string]string)
+	c.Unlock()
+
+	for _, method := range []string{"Runtime.enable", "DOM.enable", "CSS.enable", "CSS.startRuleUsageTracking"} {
+		if _, err := session.Send(method, nil); err != nil {
+			c.Lock()
+			c.cssEnabled = false
+			c.Unlock()
+			return fmt.Errorf("could not start CSS coverage: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *coverageImpl) StopCSSCoverage() ([]CSSCoverageEntry, error) {
+	c.Lock()
+	if !c.cssEnabled {
+		c.Unlock()
+		return nil, errors.New("CSSCoverage is not enabled")
+	}
+	c.cssEnabled = false
+	stylesheetURLs := c.stylesheetURLs
+	c.stylesheetURLs = nil
+	c.Unlock()
+
+	session, err := c.session()
+	if err != nil {
+		return nil, err
+	}
+	result, err := session.Send("CSS.stopRuleUsageTracking", nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not stop CSS coverage: %w", err)
+	}
+	usedRanges := make(map[string][]CoverageRange)
+	ruleUsage, _ := result["ruleUsage"].([]interface{})
+	for _, u := range ruleUsage {
+		usage := u.(map[string]interface{})
+		if used, _ := usage["used"].(bool); !used {
+			continue
+		}
+		styleSheetID := usage["styleSheetId"].(string)
+		usedRanges[styleSheetID] = append(usedRanges[styleSheetID], parseCoverageRange(usage))
+	}
+	entries := make([]CSSCoverageEntry, 0, len(stylesheetURLs))
+	for styleSheetID, url := range stylesheetURLs {
+		text, err := session.Send("CSS.getStyleSheetText", map[string]interface{}{"styleSheetId": styleSheetID})
+		if err != nil {
+			return nil, fmt.Errorf("could not get stylesheet text: %w", err)
+		}
+		entries = append(entries, CSSCoverageEntry{
+			URL:    url,
+			Text:   text["text"].(string),
+			Ranges: mergeCoverageRanges(usedRanges[styleSheetID]),
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].URL < entries[j].URL
+	})
+	for _, method := range []string{"CSS.disable", "DOM.disable"} {
+		if _, err := session.Send(method, nil); err != nil {
+			return nil, fmt.Errorf("could not stop CSS coverage: %w", err)
+		}
+	}
+	return entries, nil
+}
+
+func (c *coverageImpl) session() (CDPSession, error) {
+	session, err := c.page.cdpSession()
+	if err != nil {
+		return nil, fmt.Errorf("coverage is only supported in Chromium: %w", err)
+	}
+	c.Lock()
+	listening := c.listening
+	c.listening = true
+	c.Unlock()
+	if !listening {
+		session.On("Debugger.scriptParsed", c.onScriptParsed)
+		session.On("CSS.styleSheetAdded", c.onStyleSheetAdded)
+		session.On("Runtime.executionContextsCleared", c.onExecutionContextsCleared)
+	}
+	return session, nil
+}
+
+func (c *coverageImpl) onScriptParsed(params map[string]interface{}) {
+	c.Lock()
+	defer c.Unlock()
+	if !c.jsEnabled {
+		return
+	}
+	url, _ := params["url"].(string)
+	// Scripts injected by Playwright itself to run evaluations are never reported.
+	if url == playwrightEvaluationScriptURL || (url == "" && !c.reportAnonymousScripts) {
+		return
+	}
+	scriptID := params["scriptId"].(string)
+	c.scriptURLs[scriptID] = url
+	// fetched on another goroutine, as events are dispatched by the one reading the driver's messages
+	session := c.jsSession
+	c.pendingSources.Add(1)
+	go func() {
+		defer c.pendingSources.Done()
+		result, err := session.Send("Debugger.getScriptSource", map[string]interface{}{"scriptId": scriptID})
+		if err != nil {
+			return
+		}
+		source, _ := result["scriptSource"].(string)
+		c.Lock()
+		defer c.Unlock()
+		// the script may have been dropped by a navigation in the meantime
+		if _, ok := c.scriptURLs[scriptID]; ok {
+			c.scriptSources[scriptID] = source
+		}
+	}()
+}
+
+func (c *coverageImpl) onStyleSheetAdded(params map[string]interface{}) {
+	c.Lock()
+	defer c.Unlock()
+	if !c.cssEnabled {
+		return
+	}
+	header := params["header"].(map[string]interface{})
+	url, _ := header["sourceURL"].(string)
+	if url == "" {
+		return
+	}
+	c.stylesheetURLs[header["styleSheetId"].(string)] = url
+}
+
+func (c *coverageImpl) onExecutionContextsCleared() {
+	c.Lock()
+	defer c.Unlock()
+	if c.jsEnabled && c.jsResetOnNavigation {
+		c.scriptURLs = make(map[string]string)
+		c.scriptSources = make(map[string]string)
+	}
+	if c.cssEnabled && c.cssResetOnNavigation {
+		c.stylesheetURLs = make(map[string]string)
+	}
+}
+
+func parseCoverageRange(r map[string]interface{}) CoverageRange {
+	out := CoverageRange{
+		StartOffset: toInt(r["startOffset"]),
+		EndOffset:   toInt(r["endOffset"]),
+	}
+	if count, ok := r["count"]; ok {
+		out.Count = toInt(count)
+	}
+	return out
+}
+
+// mergeCoverageRanges sorts the ranges and joins the ones that overlap or touch, so every offset is reported once.
+func mergeCoverageRanges(ranges []CoverageRange) []CoverageRange {
+	merged := make([]CoverageRange, 0, len(ranges))
+	if len(ranges) == 0 {
+		return merged
+	}
+	sorted := append([]CoverageRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartOffset < sorted[j].StartOffset
+	})
+	current := sorted[0]
+	for _, r := range sorted[1:] {
+		if r.StartOffset <= current.EndOffset {
+			if r.EndOffset > current.EndOffset {
+				current.EndOffset = r.EndOffset
+			}
+			continue
+		}
+		merged = append(merged, current)
+		current = r
+	}
+	return append(merged, current)
+}
+
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+func newCoverage(page *pageImpl) *coverageImpl {
+	return &coverageImpl{
+		page: page,
+	}
+}
@@ -1,8 +1,50 @@
 package playwright
 
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// DialogAction is how a dialog is closed by a [DialogPolicy].
+type DialogAction string
+
+const (
+	// DialogActionAccept accepts the dialog, as if [Dialog.Accept] was called without prompt text.
+	DialogActionAccept DialogAction = "accept"
+	// DialogActionDismiss dismisses the dialog, as if [Dialog.Dismiss] was called.
+	DialogActionDismiss DialogAction = "dismiss"
+)
+
+// DialogPolicy maps dialog types (`alert`, `beforeunload`, `confirm` or `prompt`) to the action applied to dialogs of
+// that type which no [Page.OnDialog] or [BrowserContext.OnDialog] handler received. Types missing from the policy
+// are dismissed. See [BrowserContext.SetDialogPolicy].
+type DialogPolicy map[string]DialogAction
+
+// DefaultDialogPolicy returns a policy that accepts `beforeunload` dialogs, so pages can always be navigated away from
+// and closed, and dismisses all other dialogs.
+func DefaultDialogPolicy() DialogPolicy {
+	return DialogPolicy{
+		"beforeunload": DialogActionAccept,
+	}
+}
+
+func (p DialogPolicy) clone() DialogPolicy {
+	if p == nil {
+		return nil
+	}
+	policy := make(DialogPolicy, len(p))
+	for dialogType, action := range p {
+		policy[dialogType] = action
+	}
+	return policy
+}
+
+var errDialogAlreadyHandled = errors.New("Cannot handle dialog which is already handled!")
+
 type dialogImpl struct {
 	channelOwner
-	page Page
+	page    Page
+	handled atomic.Bool
 }
 
 func (d *dialogImpl) Type() string {
@@ -18,6 +60,9 @@ func (d *dialogImpl) DefaultValue() string {
 }
 
 func (d *dialogImpl) Accept(promptTextInput ...string) error {
+	if !d.handled.CompareAndSwap(false, true) {
+		return errDialogAlreadyHandled
+	}
 	var promptText *string
 	if len(promptTextInput) == 1 {
 		promptText = &promptTextInput[0]
@@ -29,6 +74,9 @@ func (d *dialogImpl) Accept(promptTextInput ...string) error {
 }
 
 func (d *dialogImpl) Dismiss() error {
+	if !d.handled.CompareAndSwap(false, true) {
+		return errDialogAlreadyHandled
+	}
 	_, err := d.channel.Send("dismiss")
 	return err
 }
@@ -37,6 +85,14 @@ func (d *dialogImpl) Page() Page {
 	return d.page
 }
 
+// applyPolicy closes a dialog that no handler received according to policy.
+func (d *dialogImpl) applyPolicy(policy DialogPolicy) error {
+	if policy[d.Type()] == DialogActionAccept {
+		return d.Accept()
+	}
+	return d.Dismiss()
+}
+
 func newDialog(parent *channelOwner, objectType string, guid string, initializer map[string]interface{}) *dialogImpl {
 	bt := &dialogImpl{}
 	bt.createChannelOwner(bt, parent, objectType, guid, initializer)
@@ -38,14 +38,14 @@ func (e *elementHandleImpl) ContentFrame() (Frame, error) {
 	return channelOwner.(*frameImpl), nil
 }
 
-func (e *elementHandleImpl) GetAttribute(name string) (string, error) {
+func (e *elementHandleImpl) GetAttribute(name string) (*string, error) {
 	attribute, err := e.channel.Send("getAttribute", map[string]interface{}{
 		"name": name,
 	})
 	if attribute == nil {
-		return "", err
+		return nil, err
 	}
-	return attribute.(string), err
+	return String(attribute.(string)), err
 }
 
 func (e *elementHandleImpl) TextContent() (string, error) {
@@ -120,7 +120,8 @@ func (e *elementHandleImpl) QuerySelectorAll(selector string) ([]ElementHandle,
 		return nil, err
 	}
 	elements := make([]ElementHandle, 0)
-	for _, channel := range channels.([]interface{}) {
+	list, _ := channels.([]interface{})
+	for _, channel := range list {
 		elements = append(elements, fromChannel(channel).(*elementHandleImpl))
 	}
 	return elements, nil
@@ -178,6 +179,10 @@ func (e *elementHandleImpl) BoundingBox() (*Rect, error) {
 	if err != nil {
 		return nil, err
 	}
+	// not rendered, e.g. display: none
+	if boundingBox == nil {
+		return nil, nil
+	}
 	out := &Rect{}
 	remapMapToStruct(boundingBox, out)
 	return out, nil
@@ -228,6 +233,16 @@ func (e *elementHandleImpl) Screenshot(options ...ElementHandleScreenshotOptions
 	var path *string
 	if len(options) > 0 {
 		path = options[0].Path
+		if waitForFonts := options[0].WaitForFonts; waitForFonts != nil {
+			option := options[0]
+			option.WaitForFonts = nil
+			options = []ElementHandleScreenshotOptions{option}
+			if *waitForFonts {
+				if _, err := e.Evaluate(`async element => { await element.ownerDocument.fonts.ready }`); err != nil {
+					return nil, fmt.Errorf("could not wait for fonts: %w", err)
+				}
+			}
+		}
 	}
 	data, err := e.channel.Send("screenshot", options)
 	if err != nil {
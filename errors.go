@@ -1,18 +1,40 @@
 package playwright
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
 
 // Error represents a Playwright error
 type Error struct {
 	Name    string `json:"name"`
 	Message string `json:"message"`
 	Stack   string `json:"stack"`
+	// callStack is the Go call stack of the API call that failed, see [Error.CallStack]
+	callStack []StackFrame
+}
+
+// StackFrame represents a single frame of a Go call stack
+type StackFrame struct {
+	File     string
+	Line     int
+	Function string
 }
 
 func (e *Error) Error() string {
 	return e.Message
 }
 
+// CallStack returns the Go call stack of the API call that failed, outermost caller last. Unlike Stack, which is the
+// JavaScript stack reported by the driver, it points at the calling Go code. It is nil for errors that weren't
+// returned by a protocol call.
+func (e *Error) CallStack() []StackFrame {
+	return e.callStack
+}
+
 func (e *Error) Is(target error) bool {
 	err, ok := target.(*Error)
 	if !ok {
@@ -27,17 +49,162 @@ func (e *Error) Is(target error) bool {
 	return e.Message == err.Message
 }
 
-// TimeoutError represents a Playwright TimeoutError
+// TimeoutError represents a Playwright TimeoutError. Errors of operations that timed out match it with [errors.Is]
+// and are a [TimeoutExceededError].
 var TimeoutError = &Error{
 	Name: "TimeoutError",
 }
 
+// TimeoutExceededError is returned when an operation did not complete within its timeout.
+type TimeoutExceededError struct {
+	err *Error
+}
+
+func (e *TimeoutExceededError) Error() string {
+	return e.err.Message
+}
+
+func (e *TimeoutExceededError) Unwrap() error {
+	return e.err
+}
+
+// TargetClosedError is returned when the page, context or browser is closed while an operation is in progress.
+type TargetClosedError struct {
+	// Reason is the reason passed to [Page.Close], if any. It replaces the default message.
+	Reason string
+	err    *Error
+}
+
+func (e *TargetClosedError) Error() string {
+	if e.Reason != "" {
+		return e.Reason
+	}
+	return e.err.Message
+}
+
+func (e *TargetClosedError) Unwrap() error {
+	return e.err
+}
+
+// FrameDetachedError is returned by operations on a [Frame] that was detached from its page, for example because its
+// iframe was removed, including operations that were still in progress when that happened.
+type FrameDetachedError struct {
+	// URL is the last URL of the frame
+	URL string
+	err error
+}
+
+func (e *FrameDetachedError) Error() string {
+	if e.err != nil {
+		return "frame was detached: " + e.err.Error()
+	}
+	return "frame was detached"
+}
+
+func (e *FrameDetachedError) Unwrap() error {
+	return e.err
+}
+
+// NavigationError is returned when a navigation fails for any other reason than a timeout or the target being
+// closed, for example a network error or an invalid URL.
+type NavigationError struct {
+	// URL is the URL that was navigated to, if known
+	URL string
+	err *Error
+}
+
+func (e *NavigationError) Error() string {
+	return e.err.Message
+}
+
+func (e *NavigationError) Unwrap() error {
+	return e.err
+}
+
+// StrictModeViolationError is returned when a strict selector, e.g. the one of a [Locator], resolves to more than
+// one element. The message lists a preview of the first matched elements.
+type StrictModeViolationError struct {
+	// Selector is the selector that matched more than one element, rendered as a locator
+	Selector string
+	// Count is the number of elements the selector resolved to
+	Count int
+	// Matches describes the first few matched elements, each with a preview and an unambiguous locator
+	Matches []string
+	err     *Error
+}
+
+func (e *StrictModeViolationError) Error() string {
+	return e.err.Message
+}
+
+func (e *StrictModeViolationError) Unwrap() error {
+	return e.err
+}
+
+var (
+	strictModeViolationPattern      = regexp.MustCompile(`strict mode violation: (.+) resolved to (\d+) elements:`)
+	strictModeViolationMatchPattern = regexp.MustCompile(`(?m)^\s+\d+\) (.+)$`)
+)
+
+func parseStrictModeViolation(err *Error) *StrictModeViolationError {
+	header := strictModeViolationPattern.FindStringSubmatch(err.Message)
+	if header == nil {
+		return nil
+	}
+	count, _ := strconv.Atoi(header[2])
+	violation := &StrictModeViolationError{
+		Selector: header[1],
+		Count:    count,
+		Matches:  make([]string, 0),
+		err:      err,
+	}
+	for _, match := range strictModeViolationMatchPattern.FindAllStringSubmatch(err.Message, -1) {
+		violation.Matches = append(violation.Matches, match[1])
+	}
+	return violation
+}
+
 func parseError(err Error) error {
-	return &Error{
+	parsed := &Error{
 		Name:    err.Name,
 		Message: err.Message,
 		Stack:   err.Stack,
 	}
+	if parsed.Name == "TargetClosedError" || IsSafeCloseError(parsed) {
+		return &TargetClosedError{err: parsed}
+	}
+	if parsed.Name == TimeoutError.Name {
+		return &TimeoutExceededError{err: parsed}
+	}
+	if violation := parseStrictModeViolation(parsed); violation != nil {
+		return violation
+	}
+	return parsed
+}
+
+// parsePageError converts an uncaught exception reported by the page. Unlike protocol errors these are never mapped to
+// [TargetClosedError], as the message comes from page code. Thrown values that aren't errors, e.g. `throw 42`, are
+// reported with an empty name and stack.
+func parsePageError(serialized map[string]interface{}) *Error {
+	pageError := &Error{}
+	if details, ok := serialized["error"].(map[string]interface{}); ok {
+		remapMapToStruct(details, pageError)
+		return pageError
+	}
+	pageError.Message = fmt.Sprintf("%v", parseResult(serialized["value"]))
+	return pageError
+}
+
+func wrapNavigationError(url string, err error) error {
+	var closed *TargetClosedError
+	if errors.As(err, &closed) || errors.Is(err, TimeoutError) {
+		return err
+	}
+	var pwErr *Error
+	if !errors.As(err, &pwErr) {
+		return err
+	}
+	return &NavigationError{URL: url, err: pwErr}
 }
 
 const (
@@ -45,9 +212,16 @@ const (
 	errMsgBrowserOrContextClosed = "Target page, context or browser has been closed"
 )
 
-func isSafeCloseError(err error) bool {
+// IsSafeCloseError reports whether err is expected when closing a page, context or browser, e.g. an operation that
+// was interrupted by the close. Such errors are usually safe to ignore in cleanup code. Errors returned by the
+// library for these cases are also a [TargetClosedError].
+func IsSafeCloseError(err error) bool {
 	if err == nil {
 		return false
 	}
+	var closed *TargetClosedError
+	if errors.As(err, &closed) {
+		return true
+	}
 	return strings.HasSuffix(err.Error(), errMsgBrowserClosed) || strings.HasSuffix(err.Error(), errMsgBrowserOrContextClosed)
 }
@@ -0,0 +1,140 @@
+package playwright
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrorShouldMapKnownErrors(t *testing.T) {
+	err := parseError(Error{Name: "TimeoutError", Message: "Timeout 30000ms exceeded."})
+	require.ErrorIs(t, err, TimeoutError)
+	var timeoutErr *TimeoutExceededError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.Equal(t, "Timeout 30000ms exceeded.", timeoutErr.Error())
+	var pwErr *Error
+	require.ErrorAs(t, err, &pwErr)
+	require.Equal(t, "Timeout 30000ms exceeded.", pwErr.Message)
+
+	err = parseError(Error{Name: "Error", Message: "page.goto: " + errMsgBrowserOrContextClosed})
+	var closed *TargetClosedError
+	require.ErrorAs(t, err, &closed)
+	require.Equal(t, "page.goto: "+errMsgBrowserOrContextClosed, err.Error())
+	require.NotErrorIs(t, err, TimeoutError)
+
+	err = parseError(Error{Name: "TargetClosedError", Message: "Target closed"})
+	require.ErrorAs(t, err, &closed)
+
+	err = parseError(Error{Name: "Error", Message: "something went wrong"})
+	require.False(t, errors.As(err, &closed))
+	require.ErrorAs(t, err, &pwErr)
+}
+
+func TestTargetClosedErrorShouldReportCloseReason(t *testing.T) {
+	err := parseError(Error{Name: "Error", Message: "page.goto: " + errMsgBrowserOrContextClosed})
+	var closed *TargetClosedError
+	require.ErrorAs(t, err, &closed)
+	closed.Reason = "test finished"
+	require.Equal(t, "test finished", err.Error())
+	require.True(t, IsSafeCloseError(err))
+	var pwErr *Error
+	require.ErrorAs(t, err, &pwErr)
+	require.Equal(t, "page.goto: "+errMsgBrowserOrContextClosed, pwErr.Message)
+}
+
+func TestIsSafeCloseError(t *testing.T) {
+	require.False(t, IsSafeCloseError(nil))
+	require.False(t, IsSafeCloseError(errors.New("something went wrong")))
+	require.True(t, IsSafeCloseError(parseError(Error{Name: "TargetClosedError", Message: "Target closed"})))
+	require.True(t, IsSafeCloseError(fmt.Errorf("could not close: %w", parseError(Error{Name: "TargetClosedError", Message: "Target closed"}))))
+	require.True(t, IsSafeCloseError(errors.New("browser.close: "+errMsgBrowserClosed)))
+	require.True(t, IsSafeCloseError(errors.New("page.click: "+errMsgBrowserOrContextClosed)))
+}
+
+func TestWrapNavigationError(t *testing.T) {
+	err := wrapNavigationError("http://localhost", parseError(Error{Name: "Error", Message: "net::ERR_CONNECTION_REFUSED"}))
+	var navErr *NavigationError
+	require.ErrorAs(t, err, &navErr)
+	require.Equal(t, "http://localhost", navErr.URL)
+	require.Equal(t, "net::ERR_CONNECTION_REFUSED", err.Error())
+
+	err = wrapNavigationError("http://localhost", parseError(Error{Name: "TimeoutError", Message: "Timeout"}))
+	require.False(t, errors.As(err, &navErr))
+	require.ErrorIs(t, err, TimeoutError)
+
+	err = wrapNavigationError("http://localhost", parseError(Error{Name: "Error", Message: errMsgBrowserClosed}))
+	require.False(t, errors.As(err, &navErr))
+}
+
+func TestProtocolCallbackShouldAttachCallStackToErrors(t *testing.T) {
+	callback := newProtocolCallback(false, make(chan struct{}))
+	callback.callStack = []StackFrame{{File: "main.go", Line: 42, Function: "main.main"}}
+	go callback.SetResult(result{
+		Error: parseError(Error{Name: "Error", Message: "failed"}),
+	})
+	_, err := callback.GetResult()
+	var pwErr *Error
+	require.ErrorAs(t, err, &pwErr)
+	require.Equal(t, "failed", pwErr.Error())
+	require.Equal(t, callback.callStack, pwErr.CallStack())
+}
+
+func TestParsePageError(t *testing.T) {
+	err := parsePageError(map[string]interface{}{
+		"error": map[string]interface{}{
+			"name":    "Error",
+			"message": errMsgBrowserOrContextClosed,
+			"stack":   "Error: boom\n    at a (script.js:1:1)",
+		},
+	})
+	require.Equal(t, &Error{
+		Name:    "Error",
+		Message: errMsgBrowserOrContextClosed,
+		Stack:   "Error: boom\n    at a (script.js:1:1)",
+	}, err)
+
+	err = parsePageError(map[string]interface{}{
+		"value": map[string]interface{}{"n": float64(42)},
+	})
+	require.Equal(t, &Error{Message: "42"}, err)
+}
+
+func TestParseErrorShouldDetectStrictModeViolations(t *testing.T) {
+	message := "Error: strict mode violation: locator('span') resolved to 12 elements:\n" +
+		"    1) <span>one</span> aka getByText('one')\n" +
+		"    2) <span>two</span> aka getByText('two')\n" +
+		"    ...\n"
+	err := parseError(Error{Name: "Error", Message: message})
+	var violation *StrictModeViolationError
+	require.ErrorAs(t, err, &violation)
+	require.Equal(t, "locator('span')", violation.Selector)
+	require.Equal(t, 12, violation.Count)
+	require.Equal(t, []string{
+		"<span>one</span> aka getByText('one')",
+		"<span>two</span> aka getByText('two')",
+	}, violation.Matches)
+	require.Equal(t, message, err.Error())
+	var pwErr *Error
+	require.ErrorAs(t, err, &pwErr)
+
+	err = parseError(Error{Name: "Error", Message: "element is not visible"})
+	require.False(t, errors.As(err, &violation))
+}
+
+func TestFrameDetachedError(t *testing.T) {
+	frame := &frameImpl{url: "http://example.com/frame.html"}
+	require.NoError(t, frame.detachedError(nil))
+	frame.detached = true
+
+	err := frame.detachedError(nil)
+	var detached *FrameDetachedError
+	require.ErrorAs(t, err, &detached)
+	require.Equal(t, "http://example.com/frame.html", detached.URL)
+	require.EqualError(t, err, "frame was detached")
+
+	err = frame.detachedError(TimeoutError)
+	require.ErrorIs(t, err, TimeoutError)
+	require.ErrorAs(t, err, &detached)
+}
@@ -14,6 +14,16 @@ type EventEmitter interface {
 	RemoveListener(name string, handler interface{})
 }
 
+// EventReplayer is implemented by every [EventEmitter] of the library, e.g. a [Page]:
+//
+//	page.(playwright.EventReplayer).OnWithReplay("response", handler)
+type EventReplayer interface {
+	// OnWithReplay is like On, but handler is first called with the most recent events of that name that were
+	// emitted before it was registered, oldest first. How many events are kept is set by
+	// [RunOptions].EventReplayBufferSize; when it is zero OnWithReplay behaves like On.
+	OnWithReplay(name string, handler interface{})
+}
+
 type (
 	eventRegister struct {
 		once []interface{}
@@ -22,12 +32,55 @@ type (
 	eventEmitter struct {
 		eventsMutex sync.Mutex
 		events      map[string]*eventRegister
+		// queue is set when handlers are called on a dedicated goroutine instead of by the emitter.
+		queue *eventQueue
+		// replaySize bounds the number of payloads kept per event name in replay for OnWithReplay.
+		replaySize int
+		replay     map[string][][]interface{}
 	}
 )
 
+// eventQueue calls queued deliveries one after another, in the order they were queued. Its goroutine only runs
+// while deliveries are pending, so idle objects don't hold on to one.
+type eventQueue struct {
+	sync.Mutex
+	pending []func()
+	running bool
+}
+
+func (q *eventQueue) push(deliver func()) {
+	q.Lock()
+	defer q.Unlock()
+	q.pending = append(q.pending, deliver)
+	if !q.running {
+		q.running = true
+		go q.drain()
+	}
+}
+
+func (q *eventQueue) drain() {
+	for {
+		q.Lock()
+		if len(q.pending) == 0 {
+			q.running = false
+			q.Unlock()
+			return
+		}
+		deliver := q.pending[0]
+		q.pending[0] = nil
+		q.pending = q.pending[1:]
+		q.Unlock()
+		deliver()
+	}
+}
+
 func (e *eventEmitter) Emit(name string, payload ...interface{}) (handled bool) {
+	if e.queue != nil {
+		return e.emitQueued(name, payload...)
+	}
 	e.eventsMutex.Lock()
 	defer e.eventsMutex.Unlock()
+	e.record(name, payload)
 	if _, ok := e.events[name]; !ok {
 		return
 	}
@@ -36,24 +89,59 @@ func (e *eventEmitter) Emit(name string, payload ...interface{}) (handled bool)
 		handled = true
 	}
 
-	payloadV := make([]reflect.Value, 0)
+	callEventHandlers(e.events[name].on, payload)
+	callEventHandlers(e.events[name].once, payload)
 
-	for _, p := range payload {
-		payloadV = append(payloadV, reflect.ValueOf(p))
-	}
+	e.events[name].once = make([]interface{}, 0)
+	return
+}
 
-	callHandlers := func(handlers []interface{}) {
-		for _, handler := range handlers {
-			handlerV := reflect.ValueOf(handler)
-			handlerV.Call(payloadV[:int(math.Min(float64(handlerV.Type().NumIn()), float64(len(payloadV))))])
-		}
+// emitQueued takes the handlers registered at the time of the call and queues their invocation, so the emitter
+// never waits for them.
+func (e *eventEmitter) emitQueued(name string, payload ...interface{}) bool {
+	e.eventsMutex.Lock()
+	defer e.eventsMutex.Unlock()
+	e.record(name, payload)
+	register, ok := e.events[name]
+	if !ok || len(register.on)+len(register.once) == 0 {
+		return false
 	}
+	handlers := make([]interface{}, 0, len(register.on)+len(register.once))
+	handlers = append(handlers, register.on...)
+	handlers = append(handlers, register.once...)
+	register.once = make([]interface{}, 0)
+	// Queued under the lock, so deliveries keep the order in which the events were emitted.
+	e.queue.push(func() {
+		callEventHandlers(handlers, payload)
+	})
+	return true
+}
 
-	callHandlers(e.events[name].on)
-	callHandlers(e.events[name].once)
+// record keeps payload for later OnWithReplay subscribers, dropping the oldest one once the buffer is full. The
+// caller must hold eventsMutex.
+func (e *eventEmitter) record(name string, payload []interface{}) {
+	if e.replaySize <= 0 {
+		return
+	}
+	if e.replay == nil {
+		e.replay = make(map[string][][]interface{})
+	}
+	buffered := append(e.replay[name], payload)
+	if len(buffered) > e.replaySize {
+		buffered = buffered[len(buffered)-e.replaySize:]
+	}
+	e.replay[name] = buffered
+}
 
-	e.events[name].once = make([]interface{}, 0)
-	return
+func callEventHandlers(handlers []interface{}, payload []interface{}) {
+	payloadV := make([]reflect.Value, 0, len(payload))
+	for _, p := range payload {
+		payloadV = append(payloadV, reflect.ValueOf(p))
+	}
+	for _, handler := range handlers {
+		handlerV := reflect.ValueOf(handler)
+		handlerV.Call(payloadV[:int(math.Min(float64(handlerV.Type().NumIn()), float64(len(payloadV))))])
+	}
 }
 
 func (e *eventEmitter) Once(name string, handler interface{}) {
@@ -64,6 +152,31 @@ func (e *eventEmitter) On(name string, handler interface{}) {
 	e.addEvent(name, handler, false)
 }
 
+func (e *eventEmitter) OnWithReplay(name string, handler interface{}) {
+	e.eventsMutex.Lock()
+	// Taking the buffer and registering under the lock makes sure no event is delivered twice or skipped in between.
+	buffered := append([][]interface{}(nil), e.replay[name]...)
+	e.addEventLocked(name, handler, false)
+	if e.queue != nil {
+		// Queued under the lock, so the replay comes before any event emitted after it.
+		if len(buffered) > 0 {
+			e.queue.push(func() {
+				for _, payload := range buffered {
+					callEventHandlers([]interface{}{handler}, payload)
+				}
+			})
+		}
+		e.eventsMutex.Unlock()
+		return
+	}
+	e.eventsMutex.Unlock()
+	// Called without the lock, so handler may use the emitter. An event emitted by another goroutine meanwhile can
+	// reach handler before the replay is done.
+	for _, payload := range buffered {
+		callEventHandlers([]interface{}{handler}, payload)
+	}
+}
+
 func (e *eventEmitter) RemoveListener(name string, handler interface{}) {
 	e.eventsMutex.Lock()
 	defer e.eventsMutex.Unlock()
@@ -107,6 +220,11 @@ func (e *eventEmitter) ListenerCount(name string) int {
 
 func (e *eventEmitter) addEvent(name string, handler interface{}, once bool) {
 	e.eventsMutex.Lock()
+	defer e.eventsMutex.Unlock()
+	e.addEventLocked(name, handler, once)
+}
+
+func (e *eventEmitter) addEventLocked(name string, handler interface{}, once bool) {
 	if _, ok := e.events[name]; !ok {
 		e.events[name] = &eventRegister{
 			on:   make([]interface{}, 0),
@@ -118,9 +236,14 @@ func (e *eventEmitter) addEvent(name string, handler interface{}, once bool) {
 	} else {
 		e.events[name].on = append(e.events[name].on, handler)
 	}
-	e.eventsMutex.Unlock()
 }
 
 func (e *eventEmitter) initEventEmitter() {
 	e.events = make(map[string]*eventRegister)
 }
+
+// initQueuedEventEmitter is like initEventEmitter, but handlers are called on a goroutine of their own.
+func (e *eventEmitter) initQueuedEventEmitter() {
+	e.initEventEmitter()
+	e.queue = &eventQueue{}
+}
@@ -110,3 +110,80 @@ func TestEventEmitterOnLessArgsAcceptingReceiver(t *testing.T) {
 	handler.Emit(testEventName)
 	<-wasCalled
 }
+
+func TestQueuedEventEmitterDoesNotBlockAndKeepsOrder(t *testing.T) {
+	handler := &eventEmitter{}
+	handler.initQueuedEventEmitter()
+	release := make(chan struct{})
+	received := make(chan int, 10)
+	handler.On(testEventName, func(i int) {
+		<-release
+		received <- i
+	})
+	onceCalls := make(chan int, 10)
+	handler.Once(testEventName, func(i int) {
+		onceCalls <- i
+	})
+	for i := 0; i < 5; i++ {
+		// Emit returns although the handler is blocked.
+		require.True(t, handler.Emit(testEventName, i))
+	}
+	require.False(t, handler.Emit(testEventNameBar, 0))
+	close(release)
+	for i := 0; i < 5; i++ {
+		require.Equal(t, i, <-received)
+	}
+	require.Equal(t, 0, <-onceCalls)
+	require.Len(t, onceCalls, 0)
+	require.Equal(t, 1, handler.ListenerCount(testEventName))
+}
+
+func TestEventEmitterOnWithReplay(t *testing.T) {
+	for _, queued := range []bool{false, true} {
+		handler := &eventEmitter{}
+		if queued {
+			handler.initQueuedEventEmitter()
+		} else {
+			handler.initEventEmitter()
+		}
+		handler.replaySize = 2
+		for i := 0; i < 3; i++ {
+			handler.Emit(testEventName, i)
+		}
+		received := make(chan int, 10)
+		handler.OnWithReplay(testEventName, func(i int) {
+			received <- i
+		})
+		handler.Emit(testEventName, 3)
+		// Only the last two events were kept, and they come before the live one.
+		for _, expected := range []int{1, 2, 3} {
+			require.Equal(t, expected, <-received)
+		}
+		require.Equal(t, 1, handler.ListenerCount(testEventName))
+	}
+}
+
+func TestEventEmitterOnWithReplayWithoutBuffer(t *testing.T) {
+	handler := &eventEmitter{}
+	handler.initEventEmitter()
+	handler.Emit(testEventName, 0)
+	received := make(chan int, 10)
+	handler.OnWithReplay(testEventName, func(i int) {
+		received <- i
+	})
+	require.Len(t, received, 0)
+	handler.Emit(testEventName, 1)
+	require.Equal(t, 1, <-received)
+}
+
+func TestEventEmitterOnWithReplayHandlerCanUseEmitter(t *testing.T) {
+	handler := &eventEmitter{}
+	handler.initEventEmitter()
+	handler.replaySize = 1
+	handler.Emit(testEventName, 0)
+	counts := make(chan int, 1)
+	handler.OnWithReplay(testEventName, func(i int) {
+		counts <- handler.ListenerCount(testEventName)
+	})
+	require.Equal(t, 1, <-counts)
+}
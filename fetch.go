@@ -298,7 +298,7 @@ func (r *apiResponseImpl) Body() ([]byte, error) {
 		},
 	})
 	if err != nil {
-		if isSafeCloseError(err) {
+		if IsSafeCloseError(err) {
 			return nil, errors.New("response has been disposed")
 		}
 		return nil, err
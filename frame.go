@@ -1,8 +1,10 @@
 package playwright
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"time"
 )
@@ -14,7 +16,7 @@ type frameImpl struct {
 	page        *pageImpl
 	name        string
 	url         string
-	parentFrame Frame
+	parentFrame *frameImpl
 	childFrames []Frame
 	loadStates  *safeStringSet
 }
@@ -36,8 +38,11 @@ func newFrame(parent *channelOwner, objectType string, guid string, initializer
 
 	channelOwner := fromNullableChannel(initializer["parentFrame"])
 	if channelOwner != nil {
-		bt.parentFrame = channelOwner.(*frameImpl)
-		bt.parentFrame.(*frameImpl).childFrames = append(bt.parentFrame.(*frameImpl).childFrames, bt)
+		parent := channelOwner.(*frameImpl)
+		bt.parentFrame = parent
+		parent.Lock()
+		parent.childFrames = append(parent.childFrames, bt)
+		parent.Unlock()
 	}
 
 	bt.channel.On("navigated", bt.onFrameNavigated)
@@ -77,7 +82,7 @@ func (f *frameImpl) Goto(url string, options ...FrameGotoOptions) (Response, err
 		"url": url,
 	}, options)
 	if err != nil {
-		return nil, err
+		return nil, wrapNavigationError(url, err)
 	}
 	channelOwner := fromNullableChannel(channel)
 	if channelOwner == nil {
@@ -88,12 +93,15 @@ func (f *frameImpl) Goto(url string, options ...FrameGotoOptions) (Response, err
 }
 
 func (f *frameImpl) AddScriptTag(options FrameAddScriptTagOptions) (ElementHandle, error) {
+	if err := checkSingleTagSource(options.URL, options.Path, options.Content); err != nil {
+		return nil, err
+	}
 	if options.Path != nil {
 		file, err := os.ReadFile(*options.Path)
 		if err != nil {
 			return nil, err
 		}
-		options.Content = String(string(file))
+		options.Content = String(string(file) + "\n//# sourceURL=" + strings.ReplaceAll(*options.Path, "\n", ""))
 		options.Path = nil
 	}
 	channel, err := f.channel.Send("addScriptTag", options)
@@ -104,12 +112,15 @@ func (f *frameImpl) AddScriptTag(options FrameAddScriptTagOptions) (ElementHandl
 }
 
 func (f *frameImpl) AddStyleTag(options FrameAddStyleTagOptions) (ElementHandle, error) {
+	if err := checkSingleTagSource(options.URL, options.Path, options.Content); err != nil {
+		return nil, err
+	}
 	if options.Path != nil {
 		file, err := os.ReadFile(*options.Path)
 		if err != nil {
 			return nil, err
 		}
-		options.Content = String(string(file))
+		options.Content = String(string(file) + "\n/*# sourceURL=" + strings.ReplaceAll(*options.Path, "\n", "") + "*/")
 		options.Path = nil
 	}
 	channel, err := f.channel.Send("addStyleTag", options)
@@ -119,10 +130,34 @@ func (f *frameImpl) AddStyleTag(options FrameAddStyleTagOptions) (ElementHandle,
 	return fromChannel(channel).(*elementHandleImpl), nil
 }
 
+// checkSingleTagSource makes sure exactly one of the sources of a script or style tag is given.
+func checkSingleTagSource(sources ...*string) error {
+	count := 0
+	for _, source := range sources {
+		if source != nil {
+			count++
+		}
+	}
+	if count != 1 {
+		return errors.New("exactly one of url, path or content must be specified")
+	}
+	return nil
+}
+
 func (f *frameImpl) Page() Page {
+	if f.page == nil {
+		return nil
+	}
 	return f.page
 }
 
+func (f *frameImpl) getCloseReason() string {
+	if f.page == nil {
+		return ""
+	}
+	return f.page.getCloseReason()
+}
+
 func (f *frameImpl) WaitForLoadState(options ...FrameWaitForLoadStateOptions) error {
 	option := FrameWaitForLoadStateOptions{}
 	if len(options) == 1 {
@@ -196,8 +231,9 @@ func (f *frameImpl) ExpectNavigation(cb func() error, options ...FrameExpectNavi
 	}
 	predicate := func(events ...interface{}) bool {
 		ev := events[0].(map[string]interface{})
+		// a failed navigation ends the wait whatever its URL, so the error is not lost
 		if ev["error"] != nil {
-			print("error")
+			return true
 		}
 		return matcher == nil || matcher.Matches(ev["url"].(string))
 	}
@@ -207,15 +243,23 @@ func (f *frameImpl) ExpectNavigation(cb func() error, options ...FrameExpectNavi
 	if err != nil || eventData == nil {
 		return nil, err
 	}
+	event := eventData.(map[string]interface{})
+	if navigationErr, ok := event["error"].(string); ok {
+		url, _ := event["url"].(string)
+		return nil, wrapNavigationError(url, &Error{Name: "Error", Message: navigationErr})
+	}
 
-	t := time.Until(deadline).Milliseconds()
-	if t > 0 {
-		err = f.waitForLoadStateImpl(string(*option.WaitUntil), Float(float64(t)), nil)
-		if err != nil {
+	// a timeout of 0 disables it, so the load state is then waited for without a deadline
+	if t := time.Until(deadline).Milliseconds(); *option.Timeout == 0 || t > 0 {
+		loadStateTimeout := Float(0)
+		if *option.Timeout != 0 {
+			loadStateTimeout = Float(float64(t))
+		}
+		if err := f.waitForLoadStateImpl(string(*option.WaitUntil), loadStateTimeout, nil); err != nil {
 			return nil, err
 		}
 	}
-	event := eventData.(map[string]interface{})
+	// same-document navigations, like anchors and history.pushState, have no request and so no response
 	if event["newDocument"] != nil && event["newDocument"].(map[string]interface{})["request"] != nil {
 		request := fromChannel(event["newDocument"].(map[string]interface{})["request"]).(*requestImpl)
 		return request.Response()
@@ -268,8 +312,9 @@ func (f *frameImpl) QuerySelector(selector string, options ...FrameQuerySelector
 	params := map[string]interface{}{
 		"selector": selector,
 	}
-	if len(options) == 1 {
-		params["strict"] = options[0].Strict
+	// without an explicit value the server falls back to the StrictSelectors option of the context
+	if len(options) == 1 && options[0].Strict != nil {
+		params["strict"] = *options[0].Strict
 	}
 	channel, err := f.channel.Send("querySelector", params)
 	if err != nil {
@@ -289,7 +334,8 @@ func (f *frameImpl) QuerySelectorAll(selector string) ([]ElementHandle, error) {
 		return nil, err
 	}
 	elements := make([]ElementHandle, 0)
-	for _, channel := range channels.([]interface{}) {
+	list, _ := channels.([]interface{})
+	for _, channel := range list {
 		elements = append(elements, fromChannel(channel).(*elementHandleImpl))
 	}
 	return elements, nil
@@ -388,7 +434,7 @@ func (f *frameImpl) DispatchEvent(selector, typ string, eventInit interface{}, o
 		"selector":  selector,
 		"type":      typ,
 		"eventInit": serializeArgument(eventInit),
-	})
+	}, options)
 	return err
 }
 
@@ -412,15 +458,15 @@ func (f *frameImpl) InnerHTML(selector string, options ...FrameInnerHTMLOptions)
 	return innerHTML.(string), err
 }
 
-func (f *frameImpl) GetAttribute(selector string, name string, options ...FrameGetAttributeOptions) (string, error) {
+func (f *frameImpl) GetAttribute(selector string, name string, options ...FrameGetAttributeOptions) (*string, error) {
 	attribute, err := f.channel.Send("getAttribute", map[string]interface{}{
 		"selector": selector,
 		"name":     name,
 	}, options)
 	if attribute == nil {
-		return "", err
+		return nil, err
 	}
-	return attribute.(string), err
+	return String(attribute.(string)), err
 }
 
 func (f *frameImpl) Hover(selector string, options ...FrameHoverOptions) error {
@@ -468,8 +514,11 @@ func (f *frameImpl) Uncheck(selector string, options ...FrameUncheckOptions) err
 	return err
 }
 
-func (f *frameImpl) WaitForTimeout(timeout float64) {
-	time.Sleep(time.Duration(timeout) * time.Millisecond)
+func (f *frameImpl) WaitForTimeout(timeout float64) error {
+	_, err := f.channel.Send("waitForTimeout", map[string]interface{}{
+		"timeout": timeout,
+	})
+	return err
 }
 
 func (f *frameImpl) WaitForFunction(expression string, arg interface{}, options ...FrameWaitForFunctionOptions) (JSHandle, error) {
@@ -477,20 +526,53 @@ func (f *frameImpl) WaitForFunction(expression string, arg interface{}, options
 	if len(options) == 1 {
 		option = options[0]
 	}
-	result, err := f.channel.Send("waitForFunction", map[string]interface{}{
+	params := map[string]interface{}{
 		"expression": expression,
 		"arg":        serializeArgument(arg),
 		"timeout":    option.Timeout,
-		"polling":    option.Polling,
-	})
+	}
+	pollingInterval, err := convertPollingInterval(option.Polling)
+	if err != nil {
+		return nil, err
+	}
+	if pollingInterval != nil {
+		params["pollingInterval"] = *pollingInterval
+	}
+	result, err := f.channel.Send("waitForFunction", params)
 	if err != nil {
 		return nil, err
 	}
-	handle := fromChannel(result)
-	if handle == nil {
+	return fromChannel(result).(JSHandle), nil
+}
+
+// convertPollingInterval maps the Polling option to the protocol's polling interval, which is omitted for `raf`.
+func convertPollingInterval(polling interface{}) (*float64, error) {
+	switch v := polling.(type) {
+	case nil:
 		return nil, nil
+	case string:
+		if v == "raf" {
+			return nil, nil
+		}
+	case *string:
+		if v == nil || *v == "raf" {
+			return nil, nil
+		}
+	case int:
+		return Float(float64(v)), nil
+	case *int:
+		if v == nil {
+			return nil, nil
+		}
+		return Float(float64(*v)), nil
+	case float64:
+		return Float(v), nil
+	case *float64:
+		return v, nil
+	case time.Duration:
+		return Float(float64(v.Milliseconds())), nil
 	}
-	return handle.(*jsHandleImpl), nil
+	return nil, fmt.Errorf("polling must be \"raf\" or an interval in milliseconds, got %v", polling)
 }
 
 func (f *frameImpl) Title() (string, error) {
@@ -502,7 +584,23 @@ func (f *frameImpl) Title() (string, error) {
 }
 
 func (f *frameImpl) ChildFrames() []Frame {
-	return f.childFrames
+	f.RLock()
+	defer f.RUnlock()
+	frames := make([]Frame, len(f.childFrames))
+	copy(frames, f.childFrames)
+	return frames
+}
+
+func (f *frameImpl) removeChildFrame(child *frameImpl) {
+	f.Lock()
+	defer f.Unlock()
+	frames := make([]Frame, 0)
+	for _, frame := range f.childFrames {
+		if frame != child {
+			frames = append(frames, frame)
+		}
+	}
+	f.childFrames = frames
 }
 
 func (f *frameImpl) Dblclick(selector string, options ...FrameDblclickOptions) error {
@@ -536,10 +634,26 @@ func (f *frameImpl) FrameElement() (ElementHandle, error) {
 }
 
 func (f *frameImpl) IsDetached() bool {
+	f.RLock()
+	defer f.RUnlock()
 	return f.detached
 }
 
+func (f *frameImpl) detachedError(cause error) error {
+	f.RLock()
+	defer f.RUnlock()
+	if !f.detached {
+		return nil
+	}
+	return &FrameDetachedError{URL: f.url, err: cause}
+}
+
 func (f *frameImpl) ParentFrame() Frame {
+	f.RLock()
+	defer f.RUnlock()
+	if f.parentFrame == nil {
+		return nil
+	}
 	return f.parentFrame
 }
 
@@ -676,6 +790,17 @@ func (f *frameImpl) Locator(selector string, options ...FrameLocatorOptions) Loc
 	return newLocator(f, selector, option)
 }
 
+// getByLocator returns the locator for a selector built by a GetBy method, or a locator reporting err when the
+// selector could not be built.
+func (f *frameImpl) getByLocator(selector string, err error) Locator {
+	if err != nil {
+		locator := newLocator(f, "")
+		locator.err = err
+		return locator
+	}
+	return f.Locator(selector)
+}
+
 func (f *frameImpl) GetByAltText(text interface{}, options ...FrameGetByAltTextOptions) Locator {
 	exact := false
 	if len(options) == 1 {
@@ -683,7 +808,7 @@ func (f *frameImpl) GetByAltText(text interface{}, options ...FrameGetByAltTextO
 			exact = true
 		}
 	}
-	return f.Locator(getByAltTextSelector(text, exact))
+	return f.getByLocator(getByAltTextSelector(text, exact))
 }
 
 func (f *frameImpl) GetByLabel(text interface{}, options ...FrameGetByLabelOptions) Locator {
@@ -693,7 +818,7 @@ func (f *frameImpl) GetByLabel(text interface{}, options ...FrameGetByLabelOptio
 			exact = true
 		}
 	}
-	return f.Locator(getByLabelSelector(text, exact))
+	return f.getByLocator(getByLabelSelector(text, exact))
 }
 
 func (f *frameImpl) GetByPlaceholder(text interface{}, options ...FrameGetByPlaceholderOptions) Locator {
@@ -703,28 +828,27 @@ func (f *frameImpl) GetByPlaceholder(text interface{}, options ...FrameGetByPlac
 			exact = true
 		}
 	}
-	return f.Locator(getByPlaceholderSelector(text, exact))
+	return f.getByLocator(getByPlaceholderSelector(text, exact))
 }
 
 func (f *frameImpl) GetByRole(role AriaRole, options ...FrameGetByRoleOptions) Locator {
 	if len(options) == 1 {
-		return f.Locator(getByRoleSelector(role, LocatorGetByRoleOptions(options[0])))
+		return f.getByLocator(getByRoleSelector(role, LocatorGetByRoleOptions(options[0])))
 	}
-	return f.Locator(getByRoleSelector(role))
+	return f.getByLocator(getByRoleSelector(role))
 }
 
 func (f *frameImpl) GetByTestId(testId interface{}) Locator {
-	return f.Locator(getByTestIdSelector(getTestIdAttributeName(), testId))
+	return f.getByLocator(getByTestIdSelector(f.connection.testIdAttributeName(), testId))
 }
 
 func (f *frameImpl) GetByText(text interface{}, options ...FrameGetByTextOptions) Locator {
-	exact := false
+	exact, ignoreDiacritics := false, false
 	if len(options) == 1 {
-		if *options[0].Exact {
-			exact = true
-		}
+		exact = options[0].Exact != nil && *options[0].Exact
+		ignoreDiacritics = options[0].IgnoreDiacritics != nil && *options[0].IgnoreDiacritics
 	}
-	return f.Locator(getByTextSelector(text, exact))
+	return f.getByLocator(getByTextSelector(text, exact, ignoreDiacritics))
 }
 
 func (f *frameImpl) GetByTitle(text interface{}, options ...FrameGetByTitleOptions) Locator {
@@ -734,7 +858,7 @@ func (f *frameImpl) GetByTitle(text interface{}, options ...FrameGetByTitleOptio
 			exact = true
 		}
 	}
-	return f.Locator(getByTitleSelector(text, exact))
+	return f.getByLocator(getByTitleSelector(text, exact))
 }
 
 func (f *frameImpl) FrameLocator(selector string) FrameLocator {
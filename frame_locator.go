@@ -24,6 +24,16 @@ func (fl *frameLocatorImpl) FrameLocator(selector string) FrameLocator {
 	return newFrameLocator(fl.frame, fl.frameSelector+" >> internal:control=enter-frame >> "+selector)
 }
 
+// getByLocator is like [frameImpl.getByLocator], for selectors inside the frame.
+func (fl *frameLocatorImpl) getByLocator(selector string, err error) Locator {
+	if err != nil {
+		locator := newLocator(fl.frame, fl.frameSelector)
+		locator.err = err
+		return locator
+	}
+	return fl.Locator(selector)
+}
+
 func (fl *frameLocatorImpl) GetByAltText(text interface{}, options ...FrameLocatorGetByAltTextOptions) Locator {
 	exact := false
 	if len(options) == 1 {
@@ -31,7 +41,7 @@ func (fl *frameLocatorImpl) GetByAltText(text interface{}, options ...FrameLocat
 			exact = true
 		}
 	}
-	return fl.Locator(getByAltTextSelector(text, exact))
+	return fl.getByLocator(getByAltTextSelector(text, exact))
 }
 
 func (fl *frameLocatorImpl) GetByLabel(text interface{}, options ...FrameLocatorGetByLabelOptions) Locator {
@@ -41,7 +51,7 @@ func (fl *frameLocatorImpl) GetByLabel(text interface{}, options ...FrameLocator
 			exact = true
 		}
 	}
-	return fl.Locator(getByLabelSelector(text, exact))
+	return fl.getByLocator(getByLabelSelector(text, exact))
 }
 
 func (fl *frameLocatorImpl) GetByPlaceholder(text interface{}, options ...FrameLocatorGetByPlaceholderOptions) Locator {
@@ -51,28 +61,27 @@ func (fl *frameLocatorImpl) GetByPlaceholder(text interface{}, options ...FrameL
 			exact = true
 		}
 	}
-	return fl.Locator(getByPlaceholderSelector(text, exact))
+	return fl.getByLocator(getByPlaceholderSelector(text, exact))
 }
 
 func (fl *frameLocatorImpl) GetByRole(role AriaRole, options ...FrameLocatorGetByRoleOptions) Locator {
 	if len(options) == 1 {
-		return fl.Locator(getByRoleSelector(role, LocatorGetByRoleOptions(options[0])))
+		return fl.getByLocator(getByRoleSelector(role, LocatorGetByRoleOptions(options[0])))
 	}
-	return fl.Locator(getByRoleSelector(role))
+	return fl.getByLocator(getByRoleSelector(role))
 }
 
 func (fl *frameLocatorImpl) GetByTestId(testId interface{}) Locator {
-	return fl.Locator(getByTestIdSelector(getTestIdAttributeName(), testId))
+	return fl.getByLocator(getByTestIdSelector(fl.frame.connection.testIdAttributeName(), testId))
 }
 
 func (fl *frameLocatorImpl) GetByText(text interface{}, options ...FrameLocatorGetByTextOptions) Locator {
-	exact := false
+	exact, ignoreDiacritics := false, false
 	if len(options) == 1 {
-		if *options[0].Exact {
-			exact = true
-		}
+		exact = options[0].Exact != nil && *options[0].Exact
+		ignoreDiacritics = options[0].IgnoreDiacritics != nil && *options[0].IgnoreDiacritics
 	}
-	return fl.Locator(getByTextSelector(text, exact))
+	return fl.getByLocator(getByTextSelector(text, exact, ignoreDiacritics))
 }
 
 func (fl *frameLocatorImpl) GetByTitle(text interface{}, options ...FrameLocatorGetByTitleOptions) Locator {
@@ -82,7 +91,7 @@ func (fl *frameLocatorImpl) GetByTitle(text interface{}, options ...FrameLocator
 			exact = true
 		}
 	}
-	return fl.Locator(getByTitleSelector(text, exact))
+	return fl.getByLocator(getByTitleSelector(text, exact))
 }
 
 func (fl *frameLocatorImpl) Last() FrameLocator {
@@ -0,0 +1,23 @@
+package playwright
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertPollingInterval(t *testing.T) {
+	for _, polling := range []interface{}{nil, "raf", String("raf"), (*string)(nil), (*int)(nil), (*float64)(nil)} {
+		interval, err := convertPollingInterval(polling)
+		require.NoError(t, err)
+		require.Nil(t, interval, "%#v", polling)
+	}
+	for _, polling := range []interface{}{100, Int(100), 100.0, Float(100), 100 * time.Millisecond} {
+		interval, err := convertPollingInterval(polling)
+		require.NoError(t, err)
+		require.Equal(t, Float(100), interval, "%#v", polling)
+	}
+	_, err := convertPollingInterval("sometimes")
+	require.ErrorContains(t, err, "polling must be")
+}
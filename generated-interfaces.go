@@ -347,7 +347,8 @@ type BrowserContext interface {
 	GrantPermissions(permissions []string, options ...BrowserContextGrantPermissionsOptions) error
 
 	// **NOTE** CDP sessions are only supported on Chromium-based browsers.
-	// Returns the newly created session.
+	// Returns the newly created session. The session is bound to the target and is detached automatically once the
+	// page is closed.
 	//
 	//  page: Target to create new session for. For backwards-compatibility, this parameter is named `page`, but it can be a
 	//    `Page` or `Frame` type.
@@ -413,6 +414,14 @@ type BrowserContext interface {
 	//  timeout: Maximum time in milliseconds
 	SetDefaultTimeout(timeout float64)
 
+	// Sets how dialogs are closed when no [BrowserContext.OnDialog] or [Page.OnDialog] handler is registered, per dialog
+	// type. Defaults to [DefaultDialogPolicy], which accepts `beforeunload` dialogs and dismisses all others. Dialogs
+	// received by a handler are never closed automatically; the handler must [Dialog.Accept] or [Dialog.Dismiss] them.
+	// The policy is copied, so changing the map afterwards has no effect.
+	//
+	//  policy: Action to apply per dialog type.
+	SetDialogPolicy(policy DialogPolicy)
+
 	// The extra HTTP headers will be sent with every request initiated by any page in the context. These headers are
 	// merged with page-specific extra HTTP headers set with [Page.SetExtraHTTPHeaders]. If page overrides a particular
 	// header, page-specific header value will be used instead of the browser context header value.
@@ -497,7 +506,8 @@ type BrowserType interface {
 
 	// Returns the persistent browser context instance.
 	// Launches browser that uses persistent storage located at “userDataDir” and returns the only context. Closing this
-	// context will automatically close the browser.
+	// context will automatically close the browser. The returned context's [BrowserContext.Browser] may be nil, so close
+	// the context itself rather than its browser.
 	//
 	//  userDataDir: Path to a User Data Directory, which stores browser session data like cookies and local storage. More details for
 	//    [Chromium](https://chromium.googlesource.com/chromium/src/+/master/docs/user_data_dir.md#introduction) and
@@ -512,7 +522,8 @@ type BrowserType interface {
 
 //  The `CDPSession` instances are used to talk raw Chrome Devtools Protocol:
 //  - protocol methods can be called with `session.send` method.
-//  - protocol events can be subscribed to with `session.on` method.
+//  - protocol events can be subscribed to with `session.on` method. Handlers receive the event parameters as a
+//    decoded `map[string]interface{}`, e.g. `session.On("Network.requestWillBeSent", func(params map[string]interface{}) {})`.
 // Useful links:
 //  - Documentation on DevTools Protocol can be found here:
 //   [DevTools Protocol Viewer].
@@ -526,10 +537,11 @@ type CDPSession interface {
 	// used to send messages.
 	Detach() error
 
+	// Sends a raw protocol command and returns its decoded result. Commands without a result return an empty map.
 	//
 	// 1. method: Protocol method name.
 	// 2. params: Optional method parameters.
-	Send(method string, params map[string]interface{}) (interface{}, error)
+	Send(method string, params map[string]interface{}) (map[string]interface{}, error)
 }
 
 // [ConsoleMessage] objects are dispatched by page via the [Page.OnConsole] event. For each console messages logged in
@@ -759,10 +771,10 @@ type ElementHandle interface {
 	// [focus]: https://developer.mozilla.org/en-US/docs/Web/API/HTMLElement/focus
 	Focus() error
 
-	// Returns element attribute value.
+	// Returns element attribute value, or nil if the attribute is missing.
 	//
 	//  name: Attribute name to get the value for.
-	GetAttribute(name string) (string, error)
+	GetAttribute(name string) (*string, error)
 
 	// This method hovers over the element by performing the following steps:
 	//  1. Wait for [actionability] checks on the element, unless “force” option is set.
@@ -1226,7 +1238,7 @@ type Frame interface {
 	//  selector: A selector to use when resolving DOM element.
 	FrameLocator(selector string) FrameLocator
 
-	// Returns element attribute value.
+	// Returns element attribute value, or nil if the attribute is missing.
 	//
 	// Deprecated: Use locator-based [Locator.GetAttribute] instead. Read more about [locators].
 	//
@@ -1235,7 +1247,7 @@ type Frame interface {
 	// 2. name: Attribute name to get the value for.
 	//
 	// [locators]: https://playwright.dev/docs/locators
-	GetAttribute(selector string, name string, options ...FrameGetAttributeOptions) (string, error)
+	GetAttribute(selector string, name string, options ...FrameGetAttributeOptions) (*string, error)
 
 	// Allows locating elements by their alt text.
 	//
@@ -1695,11 +1707,12 @@ type Frame interface {
 	// Waits for the given “timeout” in milliseconds.
 	// Note that `frame.waitForTimeout()` should only be used for debugging. Tests using the timer in production are going
 	// to be flaky. Use signals such as network events, selectors becoming visible and others instead.
+	// Returns an error without waiting any longer if the page of the frame is closed in the meantime.
 	//
 	// Deprecated: Never wait for timeout in production. Tests that wait for time are inherently flaky. Use [Locator] actions and web assertions that wait automatically.
 	//
 	//  timeout: A timeout to wait for
-	WaitForTimeout(timeout float64)
+	WaitForTimeout(timeout float64) error
 
 	// Waits for the frame to navigate to the given URL.
 	//
@@ -1862,6 +1875,12 @@ type JSHandle interface {
 	// an error if the object has circular references.
 	JSONValue() (interface{}, error)
 
+	// Unmarshals the JSON representation of the object (see [JSHandle.JSONValue]) into the value pointed to by “v”,
+	// following the rules of [json.Unmarshal].
+	//
+	//  v: pointer to the value to unmarshal into
+	JSONValueAs(v interface{}) error
+
 	String() string
 }
 
@@ -1924,6 +1943,14 @@ type Keyboard interface {
 	// [here]: https://developer.mozilla.org/en-US/docs/Web/API/KeyboardEvent/key/Key_Values
 	Press(key string, options ...KeyboardPressOptions) error
 
+	// Presses “key” while holding down “modifiers”: every modifier is pressed down in the given order, then “key” is
+	// pressed, then the modifiers are released in reverse order. It is the programmatic equivalent of
+	// `Press("Control+Shift+T")`.
+	//
+	// 1. key: Name of the key to press or a character to generate, such as `ArrowLeft` or `a`.
+	// 2. modifiers: Modifier keys to hold down while pressing “key”.
+	PressWithModifiers(key string, modifiers []KeyboardModifier, options ...KeyboardPressOptions) error
+
 	// Sends a `keydown`, `keypress`/`input`, and `keyup` event for each character in the text.
 	// To press a special key, like `Control` or `ArrowDown`, use [Keyboard.Press].
 	//
@@ -1939,6 +1966,10 @@ type Keyboard interface {
 // Locators are the central piece of Playwright's auto-waiting and retry-ability. In a nutshell, locators represent a
 // way to find element(s) on the page at any moment. A locator can be created with the [Page.Locator] method.
 // [Learn more about locators].
+// Actions on a locator resolve the selector again on every attempt. When the element is detached between being
+// resolved and acted upon, e.g. because the page re-rendered it, the action is retried against the new element until
+// the timeout elapses. An [ElementHandle] is bound to a single element and fails with an "Element is not attached to
+// the DOM" error instead.
 //
 // [Learn more about locators]: https://playwright.dev/docs/locators
 type Locator interface {
@@ -2035,6 +2066,9 @@ type Locator interface {
 	Click(options ...LocatorClickOptions) error
 
 	// Returns the number of elements matching the locator.
+	// **NOTE** Every call is a round-trip to the browser and the result is not cached, since the DOM may change between
+	// calls. To compute something over all matching elements, prefer a single [Locator.EvaluateAll] over iterating with
+	// [Locator.Nth].
 	Count() (int, error)
 
 	// Double-click an element.
@@ -2190,10 +2224,10 @@ type Locator interface {
 	//  selector: A selector to use when resolving DOM element.
 	FrameLocator(selector string) FrameLocator
 
-	// Returns the matching element's attribute value.
+	// Returns the matching element's attribute value, or nil if the attribute is missing.
 	//
 	//  name: Attribute name to get the value for.
-	GetAttribute(name string, options ...LocatorGetAttributeOptions) (string, error)
+	GetAttribute(name string, options ...LocatorGetAttributeOptions) (*string, error)
 
 	// Allows locating elements by their alt text.
 	//
@@ -2344,7 +2378,9 @@ type Locator interface {
 	// [Learn more about locators]: https://playwright.dev/docs/locators
 	Locator(selectorOrLocator interface{}, options ...LocatorLocatorOptions) Locator
 
-	// Returns locator to the n-th matching element. It's zero based, `nth(0)` selects the first element.
+	// Returns locator to the n-th matching element. It's zero based, `nth(0)` selects the first element. Negative
+	// indices count from the end, `nth(-1)` selects the last element.
+	// **NOTE** Like [Locator.First] and [Locator.Last], this only composes the selector and does not talk to the browser.
 	Nth(index int) Locator
 
 	// Creates a locator that matches either of the two locators.
@@ -2380,6 +2416,19 @@ type Locator interface {
 	// [here]: https://developer.mozilla.org/en-US/docs/Web/API/KeyboardEvent/key/Key_Values
 	Press(key string, options ...LocatorPressOptions) error
 
+	// **NOTE** In most cases, you should use [Locator.Fill] instead. You only need to press keys one by one if there is
+	// special keyboard handling on the page.
+	// Focuses the element, and then sends a `keydown`, `keypress`/`input`, and `keyup` event for each character in the
+	// text.
+	// To press a special key, like `Control` or `ArrowDown`, use [Locator.Press].
+	//
+	//  text: String of characters to sequentially press into a focused element.
+	PressSequentially(text string, options ...LocatorPressSequentiallyOptions) error
+
+	// Returns the full internal selector the locator resolves to, including every chained locator and filter. Useful
+	// for debugging why a locator matches (or does not match) a given element.
+	ResolvedSelector() string
+
 	// Take a screenshot of the element matching the locator.
 	//
 	// # Details
@@ -2487,12 +2536,12 @@ type Locator interface {
 	// [`node.textContent`]: https://developer.mozilla.org/en-US/docs/Web/API/Node/textContent
 	TextContent(options ...LocatorTextContentOptions) (string, error)
 
-	// **NOTE** In most cases, you should use [Locator.Fill] instead. You only need to type characters if there is special
-	// keyboard handling on the page.
 	// Focuses the element, and then sends a `keydown`, `keypress`/`input`, and `keyup` event for each character in the
 	// text.
 	// To press a special key, like `Control` or `ArrowDown`, use [Locator.Press].
 	//
+	// Deprecated: In most cases, you should use [Locator.Fill] instead. You only need to press keys one by one if there is special keyboard handling on the page - in this case use [Locator.PressSequentially].
+	//
 	//  text: A text to type into a focused element.
 	Type(text string, options ...LocatorTypeOptions) error
 
@@ -2728,8 +2777,9 @@ type Page interface {
 	// [`load`]: https://developer.mozilla.org/en-US/docs/Web/Events/load
 	OnLoad(fn func(Page))
 
-	// Emitted when an uncaught exception happens within the page.
-	OnPageError(fn func(*Error))
+	// Emitted when an uncaught exception happens within the page. The error is an [*Error] carrying the JavaScript
+	// error name, message and stack. Uncaught exceptions are reported independently of [Page.OnConsole].
+	OnPageError(fn func(error))
 
 	// Emitted when the page opens a new tab or window. This event is emitted in addition to the [BrowserContext.OnPage],
 	// but only for popups relevant to this page.
@@ -2755,10 +2805,26 @@ type Page interface {
 	// sequence of events is `request`, `response` and `requestfinished`.
 	OnRequestFinished(fn func(Request))
 
+	// Same as [Page.OnRequest], but only invokes the handler for requests whose URL matches “url”.
+	//
+	// 1. url: A glob pattern, regex pattern or predicate receiving [URL] to match. When a “baseURL” via the context
+	//    options was provided and the passed URL is a path, it gets merged via the
+	//    [`new URL()`](https://developer.mozilla.org/en-US/docs/Web/API/URL/URL) constructor.
+	// 2. fn: handler to invoke for matching requests
+	OnRequestMatching(url interface{}, fn func(Request))
+
 	// Emitted when [response] status and headers are received for a request. For a successful response, the sequence of
 	// events is `request`, `response` and `requestfinished`.
 	OnResponse(fn func(Response))
 
+	// Same as [Page.OnResponse], but only invokes the handler for responses whose URL matches “url”.
+	//
+	// 1. url: A glob pattern, regex pattern or predicate receiving [URL] to match. When a “baseURL” via the context
+	//    options was provided and the passed URL is a path, it gets merged via the
+	//    [`new URL()`](https://developer.mozilla.org/en-US/docs/Web/API/URL/URL) constructor.
+	// 2. fn: handler to invoke for matching responses
+	OnResponseMatching(url interface{}, fn func(Response))
+
 	// Emitted when [WebSocket] request is sent.
 	OnWebSocket(fn func(WebSocket))
 
@@ -2843,6 +2909,10 @@ type Page interface {
 	// Get the browser context that the page belongs to.
 	Context() BrowserContext
 
+	// Coverage object associated with this page, used to gather JavaScript and CSS coverage.
+	// **NOTE** Coverage APIs are only supported on Chromium-based browsers.
+	Coverage() Coverage
+
 	// This method double clicks an element matching “selector” by performing the following steps:
 	//  1. Find an element matching “selector”. If there is none, wait until a matching element is attached to the DOM.
 	//  2. Wait for [actionability] checks on the matched element, unless “force” option is set. If
@@ -2899,6 +2969,13 @@ type Page interface {
 	// feature, using the `colorScheme` argument.
 	EmulateMedia(options ...PageEmulateMediaOptions) error
 
+	// Emulates network conditions such as being offline, added latency or limited throughput for the page. Use one of
+	// the presets like [Slow3G] or [Fast3G], or [NoNetworkThrottling] to restore the default network.
+	// **NOTE** Network conditions emulation is only supported in Chromium.
+	//
+	//  conditions: Network conditions to emulate.
+	EmulateNetworkConditions(conditions NetworkConditions) error
+
 	// The method finds an element matching the specified selector within the page and passes it as a first argument to
 	// “expression”. If no elements match the selector, the method throws an error. Returns the value of “expression”.
 	// If “expression” returns a [Promise], then [Page.EvalOnSelector] would wait for the promise to resolve and return
@@ -3013,7 +3090,7 @@ type Page interface {
 	// An array of all frames attached to the page.
 	Frames() []Frame
 
-	// Returns element attribute value.
+	// Returns element attribute value, or nil if the attribute is missing.
 	//
 	// Deprecated: Use locator-based [Locator.GetAttribute] instead. Read more about [locators].
 	//
@@ -3022,7 +3099,7 @@ type Page interface {
 	// 2. name: Attribute name to get the value for.
 	//
 	// [locators]: https://playwright.dev/docs/locators
-	GetAttribute(selector string, name string, options ...PageGetAttributeOptions) (string, error)
+	GetAttribute(selector string, name string, options ...PageGetAttributeOptions) (*string, error)
 
 	// Allows locating elements by their alt text.
 	//
@@ -3373,6 +3450,14 @@ type Page interface {
 	// Returns the buffer with the captured screenshot.
 	Screenshot(options ...PageScreenshotOptions) ([]byte, error)
 
+	// Returns a screenshot of the region covered by “loc” and “padding” CSS pixels around it on every side. The element
+	// is scrolled into view first, and the region is clipped to the viewport. “Clip” and “FullPage” of “options” are
+	// ignored, the other options apply as for [Page.Screenshot].
+	//
+	// 1. loc: Locator of the element to capture, in this page or one of its frames.
+	// 2. padding: Number of CSS pixels captured around the element's bounding box. Must not be negative.
+	ScreenshotOfRegion(loc Locator, padding int, options ...PageScreenshotOptions) ([]byte, error)
+
 	// This method waits for an element matching “selector”, waits for [actionability] checks, waits
 	// until all specified options are present in the `<select>` element and selects these options.
 	// If the target element is not a `<select>` element, this method throws an error. However, if the element is inside
@@ -3647,11 +3732,12 @@ type Page interface {
 	// Waits for the given “timeout” in milliseconds.
 	// Note that `page.waitForTimeout()` should only be used for debugging. Tests using the timer in production are going
 	// to be flaky. Use signals such as network events, selectors becoming visible and others instead.
+	// Returns an error without waiting any longer if the page is closed in the meantime.
 	//
 	// Deprecated: Never wait for timeout in production. Tests that wait for time are inherently flaky. Use [Locator] actions and web assertions that wait automatically.
 	//
 	//  timeout: A timeout to wait for
-	WaitForTimeout(timeout float64)
+	WaitForTimeout(timeout float64) error
 
 	// Waits for the main frame to navigate to the given URL.
 	//
@@ -3742,7 +3828,8 @@ type Request interface {
 	// An object with all the request HTTP headers associated with this request. The header names are lower-cased.
 	AllHeaders() (map[string]string, error)
 
-	// The method returns `null` unless this request has failed, as reported by `requestfailed` event.
+	// The method returns `null` unless this request has failed, as reported by `requestfailed` event. The returned
+	// error carries the failure text, e.g. `net::ERR_ABORTED`.
 	Failure() error
 
 	// Returns the [Frame] that initiated this request.
@@ -3818,7 +3905,8 @@ type Response interface {
 	// Returns the buffer with response body.
 	Body() ([]byte, error)
 
-	// Waits for this response to finish, returns always `null`.
+	// Waits for this response to finish. Returns `nil` once the body is fully received, or the [Request.Failure] if the
+	// request failed after the response headers were received.
 	Finished() error
 
 	// Returns the [Frame] that initiated this response.
@@ -3943,7 +4031,7 @@ type Selectors interface {
 type Touchscreen interface {
 	// Dispatches a `touchstart` and `touchend` event with a single touch at the position (“x”,“y”).
 	// **NOTE** [Page.Tap] the method will throw if “hasTouch” option of the browser context is false.
-	Tap(x int, y int) error
+	Tap(x float64, y float64) error
 }
 
 // API for collecting and saving Playwright traces. Playwright traces can be opened in
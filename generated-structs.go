@@ -666,6 +666,15 @@ type BrowserTypeConnectOptions struct {
 	ExposeNetwork *string `json:"exposeNetwork"`
 	// Additional HTTP headers to be sent with web socket connect request. Optional.
 	Headers map[string]string `json:"headers"`
+	// Interval in milliseconds at which the connection is checked with a round trip to the remote server. When a check
+	// is not answered within “keepAliveTimeout”, the connection is considered dead: pending calls fail and the browser
+	// emits the `disconnected` event. The web socket is owned by the driver, so no read deadline can be set on it
+	// instead. Only [BrowserType.Connect] takes this option: [BrowserType.ConnectOverCDP] talks to the local driver,
+	// whose calls to the browser are bounded by their own timeouts. Defaults to `0` (no keepalive).
+	KeepAliveInterval *float64 `json:"keepAliveInterval"`
+	// Maximum time in milliseconds to wait for the answer to a keepalive check, see “keepAliveInterval”. Defaults to
+	// `30000` (30 seconds).
+	KeepAliveTimeout *float64 `json:"keepAliveTimeout"`
 	// Slows down Playwright operations by the specified amount of milliseconds. Useful so that you can see what is going
 	// on. Defaults to 0.
 	SlowMo *float64 `json:"slowMo"`
@@ -1107,6 +1116,9 @@ type ElementHandleScreenshotOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// Specify screenshot type, defaults to `png`.
 	Type *ScreenshotType `json:"type"`
+	// When true, waits for `document.fonts.ready` in the captured documents before taking the screenshot, so web fonts
+	// that load late do not make it flaky. Defaults to `false`.
+	WaitForFonts *bool `json:"waitForFonts"`
 }
 type ElementHandleScrollIntoViewIfNeededOptions struct {
 	// Maximum time in milliseconds. Defaults to `30000` (30 seconds). Pass `0` to disable timeout. The default value can
@@ -1489,6 +1501,10 @@ type FrameGetByRoleOptions struct {
 	// [accessible name]: https://w3c.github.io/accname/#dfn-accessible-name
 	// [accessible name]: https://w3c.github.io/accname/#dfn-accessible-name
 	Name interface{} `json:"name"`
+	// Whether to trim a string “name” and collapse its runs of whitespace, including line breaks, into single spaces
+	// before matching. Normalization happens before “exact” is applied. Ignored when “name” is a regular expression.
+	// Defaults to false.
+	NormalizeWhitespace *bool `json:"normalizeWhitespace"`
 	// An attribute that is usually set by `aria-pressed`.
 	// Learn more about [`aria-pressed`].
 	//
@@ -1504,6 +1520,10 @@ type FrameGetByTextOptions struct {
 	// Whether to find an exact match: case-sensitive and whole-string. Default to false. Ignored when locating by a
 	// regular expression. Note that exact match still trims whitespace.
 	Exact *bool `json:"exact"`
+	// Whether accented Latin letters match their unaccented form and each other, so “cafe” finds “Café”. The text is
+	// sent as a regular expression with each letter widened to its accented variants; ranges and classes in a
+	// [*regexp.Regexp] are not widened. Defaults to false.
+	IgnoreDiacritics *bool `json:"ignoreDiacritics"`
 }
 type FrameGetByTitleOptions struct {
 	// Whether to find an exact match: case-sensitive and whole-string. Default to false. Ignored when locating by a
@@ -1642,6 +1662,9 @@ type FrameLocatorOptions struct {
 	// passed a [string], matching is case-insensitive and searches for a substring. For example, `"Playwright"` matches
 	// `<article><div>Playwright</div></article>`.
 	HasText interface{} `json:"hasText"`
+	// When [HasText] or [HasNotText] is a [*regexp.Regexp], matches every run of whitespace in the pattern against any run
+	// of whitespace in the element text. Strings are always matched with normalized whitespace.
+	NormalizeWhitespace *bool `json:"normalizeWhitespace"`
 }
 type FramePressOptions struct {
 	// Time to wait between `keydown` and `keyup` in milliseconds. Defaults to 0.
@@ -1804,7 +1827,8 @@ type FrameUncheckOptions struct {
 type FrameWaitForFunctionOptions struct {
 	// If “polling” is `raf`, then “expression” is constantly executed in `requestAnimationFrame` callback. If “polling”
 	// is a number, then it is treated as an interval in milliseconds at which the function would be executed. Defaults to
-	// `raf`.
+	// `raf`. Accepts `"raf"`, an `int` or `float64` number of milliseconds, a pointer to either (nil meaning `raf`), or a
+	// [time.Duration].
 	Polling interface{} `json:"polling"`
 	// Maximum time to wait for in milliseconds. Defaults to `30000` (30 seconds). Pass `0` to disable timeout. The
 	// default value can be changed by using the [BrowserContext.SetDefaultTimeout] or [Page.SetDefaultTimeout] methods.
@@ -1926,6 +1950,10 @@ type FrameLocatorGetByRoleOptions struct {
 	// [accessible name]: https://w3c.github.io/accname/#dfn-accessible-name
 	// [accessible name]: https://w3c.github.io/accname/#dfn-accessible-name
 	Name interface{} `json:"name"`
+	// Whether to trim a string “name” and collapse its runs of whitespace, including line breaks, into single spaces
+	// before matching. Normalization happens before “exact” is applied. Ignored when “name” is a regular expression.
+	// Defaults to false.
+	NormalizeWhitespace *bool `json:"normalizeWhitespace"`
 	// An attribute that is usually set by `aria-pressed`.
 	// Learn more about [`aria-pressed`].
 	//
@@ -1941,6 +1969,10 @@ type FrameLocatorGetByTextOptions struct {
 	// Whether to find an exact match: case-sensitive and whole-string. Default to false. Ignored when locating by a
 	// regular expression. Note that exact match still trims whitespace.
 	Exact *bool `json:"exact"`
+	// Whether accented Latin letters match their unaccented form and each other, so “cafe” finds “Café”. The text is
+	// sent as a regular expression with each letter widened to its accented variants; ranges and classes in a
+	// [*regexp.Regexp] are not widened. Defaults to false.
+	IgnoreDiacritics *bool `json:"ignoreDiacritics"`
 }
 type FrameLocatorGetByTitleOptions struct {
 	// Whether to find an exact match: case-sensitive and whole-string. Default to false. Ignored when locating by a
@@ -1963,6 +1995,9 @@ type FrameLocatorLocatorOptions struct {
 	// passed a [string], matching is case-insensitive and searches for a substring. For example, `"Playwright"` matches
 	// `<article><div>Playwright</div></article>`.
 	HasText interface{} `json:"hasText"`
+	// When [HasText] or [HasNotText] is a [*regexp.Regexp], matches every run of whitespace in the pattern against any run
+	// of whitespace in the element text. Strings are always matched with normalized whitespace.
+	NormalizeWhitespace *bool `json:"normalizeWhitespace"`
 }
 type KeyboardPressOptions struct {
 	// Time to wait between `keydown` and `keyup` in milliseconds. Defaults to 0.
@@ -2147,6 +2182,9 @@ type LocatorFilterOptions struct {
 	// passed a [string], matching is case-insensitive and searches for a substring. For example, `"Playwright"` matches
 	// `<article><div>Playwright</div></article>`.
 	HasText interface{} `json:"hasText"`
+	// When [HasText] or [HasNotText] is a [*regexp.Regexp], matches every run of whitespace in the pattern against any run
+	// of whitespace in the element text. Strings are always matched with normalized whitespace.
+	NormalizeWhitespace *bool `json:"normalizeWhitespace"`
 }
 type LocatorFocusOptions struct {
 	// Maximum time in milliseconds. Defaults to `30000` (30 seconds). Pass `0` to disable timeout. The default value can
@@ -2213,6 +2251,10 @@ type LocatorGetByRoleOptions struct {
 	// [accessible name]: https://w3c.github.io/accname/#dfn-accessible-name
 	// [accessible name]: https://w3c.github.io/accname/#dfn-accessible-name
 	Name interface{} `json:"name"`
+	// Whether to trim a string “name” and collapse its runs of whitespace, including line breaks, into single spaces
+	// before matching. Normalization happens before “exact” is applied. Ignored when “name” is a regular expression.
+	// Defaults to false.
+	NormalizeWhitespace *bool `json:"normalizeWhitespace"`
 	// An attribute that is usually set by `aria-pressed`.
 	// Learn more about [`aria-pressed`].
 	//
@@ -2228,6 +2270,10 @@ type LocatorGetByTextOptions struct {
 	// Whether to find an exact match: case-sensitive and whole-string. Default to false. Ignored when locating by a
 	// regular expression. Note that exact match still trims whitespace.
 	Exact *bool `json:"exact"`
+	// Whether accented Latin letters match their unaccented form and each other, so “cafe” finds “Café”. The text is
+	// sent as a regular expression with each letter widened to its accented variants; ranges and classes in a
+	// [*regexp.Regexp] are not widened. Defaults to false.
+	IgnoreDiacritics *bool `json:"ignoreDiacritics"`
 }
 type LocatorGetByTitleOptions struct {
 	// Whether to find an exact match: case-sensitive and whole-string. Default to false. Ignored when locating by a
@@ -2319,6 +2365,9 @@ type LocatorLocatorOptions struct {
 	// passed a [string], matching is case-insensitive and searches for a substring. For example, `"Playwright"` matches
 	// `<article><div>Playwright</div></article>`.
 	HasText interface{} `json:"hasText"`
+	// When [HasText] or [HasNotText] is a [*regexp.Regexp], matches every run of whitespace in the pattern against any run
+	// of whitespace in the element text. Strings are always matched with normalized whitespace.
+	NormalizeWhitespace *bool `json:"normalizeWhitespace"`
 }
 type LocatorPressOptions struct {
 	// Time to wait between `keydown` and `keyup` in milliseconds. Defaults to 0.
@@ -2365,6 +2414,9 @@ type LocatorScreenshotOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// Specify screenshot type, defaults to `png`.
 	Type *ScreenshotType `json:"type"`
+	// When true, waits for `document.fonts.ready` in the captured documents before taking the screenshot, so web fonts
+	// that load late do not make it flaky. Defaults to `false`.
+	WaitForFonts *bool `json:"waitForFonts"`
 }
 type LocatorScrollIntoViewIfNeededOptions struct {
 	// Maximum time in milliseconds. Defaults to `30000` (30 seconds). Pass `0` to disable timeout. The default value can
@@ -2706,6 +2758,8 @@ type PageClickOptions struct {
 	Trial *bool `json:"trial"`
 }
 type PageCloseOptions struct {
+	// The reason to be reported to the operations interrupted by the page closure.
+	Reason *string `json:"reason"`
 	// Defaults to `false`. Whether to run the
 	// [before unload] page handlers.
 	//
@@ -2888,6 +2942,10 @@ type PageGetByRoleOptions struct {
 	// [accessible name]: https://w3c.github.io/accname/#dfn-accessible-name
 	// [accessible name]: https://w3c.github.io/accname/#dfn-accessible-name
 	Name interface{} `json:"name"`
+	// Whether to trim a string “name” and collapse its runs of whitespace, including line breaks, into single spaces
+	// before matching. Normalization happens before “exact” is applied. Ignored when “name” is a regular expression.
+	// Defaults to false.
+	NormalizeWhitespace *bool `json:"normalizeWhitespace"`
 	// An attribute that is usually set by `aria-pressed`.
 	// Learn more about [`aria-pressed`].
 	//
@@ -2903,6 +2961,10 @@ type PageGetByTextOptions struct {
 	// Whether to find an exact match: case-sensitive and whole-string. Default to false. Ignored when locating by a
 	// regular expression. Note that exact match still trims whitespace.
 	Exact *bool `json:"exact"`
+	// Whether accented Latin letters match their unaccented form and each other, so “cafe” finds “Café”. The text is
+	// sent as a regular expression with each letter widened to its accented variants; ranges and classes in a
+	// [*regexp.Regexp] are not widened. Defaults to false.
+	IgnoreDiacritics *bool `json:"ignoreDiacritics"`
 }
 type PageGetByTitleOptions struct {
 	// Whether to find an exact match: case-sensitive and whole-string. Default to false. Ignored when locating by a
@@ -3069,6 +3131,9 @@ type PageLocatorOptions struct {
 	// passed a [string], matching is case-insensitive and searches for a substring. For example, `"Playwright"` matches
 	// `<article><div>Playwright</div></article>`.
 	HasText interface{} `json:"hasText"`
+	// When [HasText] or [HasNotText] is a [*regexp.Regexp], matches every run of whitespace in the pattern against any run
+	// of whitespace in the element text. Strings are always matched with normalized whitespace.
+	NormalizeWhitespace *bool `json:"normalizeWhitespace"`
 }
 type PagePdfOptions struct {
 	// Display header and footer. Defaults to `false`.
@@ -3196,6 +3261,9 @@ type PageScreenshotOptions struct {
 	Timeout *float64 `json:"timeout"`
 	// Specify screenshot type, defaults to `png`.
 	Type *ScreenshotType `json:"type"`
+	// When true, waits for `document.fonts.ready` in the captured documents before taking the screenshot, so web fonts
+	// that load late do not make it flaky. Defaults to `false`.
+	WaitForFonts *bool `json:"waitForFonts"`
 }
 type PageSelectOptionOptions struct {
 	// Whether to bypass the [actionability] checks. Defaults to `false`.
@@ -3373,7 +3441,8 @@ type PageExpectFileChooserOptions struct {
 type PageWaitForFunctionOptions struct {
 	// If “polling” is `raf`, then “expression” is constantly executed in `requestAnimationFrame` callback. If “polling”
 	// is a number, then it is treated as an interval in milliseconds at which the function would be executed. Defaults to
-	// `raf`.
+	// `raf`. Accepts `"raf"`, an `int` or `float64` number of milliseconds, a pointer to either (nil meaning `raf`), or a
+	// [time.Duration].
 	Polling interface{} `json:"polling"`
 	// Maximum time to wait for in milliseconds. Defaults to `30000` (30 seconds). Pass `0` to disable timeout. The
 	// default value can be changed by using the [BrowserContext.SetDefaultTimeout] or [Page.SetDefaultTimeout] methods.
@@ -2,7 +2,6 @@ package playwright
 
 import (
 	"errors"
-	"log"
 )
 
 type harRouter struct {
@@ -20,7 +19,7 @@ func (r *harRouter) addContextRoute(context BrowserContext) error {
 	err := context.Route(r.urlOrPredicate, func(route Route) {
 		err := r.handle(route)
 		if err != nil {
-			log.Println(err)
+			r.localUtils.connection.logger.Errorf("could not handle route from HAR: %v", err)
 		}
 	})
 	if err != nil {
@@ -37,7 +36,7 @@ func (r *harRouter) addPageRoute(page Page) error {
 	err := page.Route(r.urlOrPredicate, func(route Route) {
 		err := r.handle(route)
 		if err != nil {
-			log.Println(err)
+			r.localUtils.connection.logger.Errorf("could not handle route from HAR: %v", err)
 		}
 	})
 	if err != nil {
@@ -89,7 +88,7 @@ func (r *harRouter) handle(route Route) error {
 			Headers: deserializeNameAndValueToMap(response.Headers),
 		})
 	case "error":
-		log.Printf("har action error: %v", *response.Message)
+		r.localUtils.connection.logger.Warnf("har action error: %v", *response.Message)
 		fallthrough
 	case "noentry":
 	}
@@ -1,15 +1,14 @@
 package playwright
 
 import (
+	"errors"
 	"fmt"
-	"path"
+	"net/url"
 	"reflect"
 	"regexp"
 	"strings"
 	"sync"
 	"sync/atomic"
-
-	"github.com/danwakefield/fnmatch"
 )
 
 type (
@@ -171,24 +170,21 @@ func remapMapToStruct(inputMap interface{}, outStruct interface{}) {
 
 type urlMatcher struct {
 	urlOrPredicate interface{}
+	// glob is the compiled form of a string pattern, resolved against the base URL.
+	glob *regexp.Regexp
 }
 
 func newURLMatcher(urlOrPredicate, baseURL interface{}) *urlMatcher {
-	if baseURL != nil {
-		url, ok := urlOrPredicate.(string)
-		if ok && !strings.HasPrefix(url, "*") {
-			base, ok := baseURL.(*string)
-			if ok && base != nil {
-				url = path.Join(*base, url)
-				return &urlMatcher{
-					urlOrPredicate: url,
-				}
-			}
-		}
-	}
-	return &urlMatcher{
+	matcher := &urlMatcher{
 		urlOrPredicate: urlOrPredicate,
 	}
+	if pattern, ok := urlOrPredicate.(string); ok {
+		if base, ok := baseURL.(*string); ok && base != nil && !strings.HasPrefix(pattern, "*") {
+			pattern = resolveURL(*base, pattern)
+		}
+		matcher.glob = globToRegex(pattern)
+	}
+	return matcher
 }
 
 func (u *urlMatcher) Matches(url string) bool {
@@ -196,7 +192,7 @@ func (u *urlMatcher) Matches(url string) bool {
 	case *regexp.Regexp:
 		return v.MatchString(url)
 	case string:
-		return fnmatch.Match(v, url, 0)
+		return v == "" || u.glob.MatchString(url)
 	}
 	if reflect.TypeOf(u.urlOrPredicate).Kind() == reflect.Func {
 		function := reflect.ValueOf(u.urlOrPredicate)
@@ -206,6 +202,126 @@ func (u *urlMatcher) Matches(url string) bool {
 	panic(u.urlOrPredicate)
 }
 
+// URLMatches reports whether url matches the glob pattern using the same rules as [Page.Route], [Page.WaitForURL]
+// and the other URL-filtering methods. Patterns that don't start with `*` are first resolved against baseURL, the
+// same way navigation resolves relative URLs; pass an empty baseURL to match the pattern as-is. In the pattern:
+//   - `*` matches any characters except `/`
+//   - `**` as a whole path segment matches any number of segments, including none
+//   - `?` matches any single character
+//   - `{a,b}` matches either of the comma separated alternatives
+//   - `[...]` matches a character class
+//   - `\` escapes the following character
+//
+// An empty pattern matches any URL.
+func URLMatches(baseURL, pattern, url string) bool {
+	var base *string
+	if baseURL != "" {
+		base = &baseURL
+	}
+	return newURLMatcher(pattern, base).Matches(url)
+}
+
+// resolveURL resolves target against base like the URL constructor does, returning target unchanged when either of
+// them can't be parsed.
+func resolveURL(base, target string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return target
+	}
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+	return baseURL.ResolveReference(targetURL).String()
+}
+
+// validateProxy checks the proxy server before it is sent, the short form `host:port` is treated as an HTTP proxy.
+func validateProxy(proxy *Proxy) error {
+	if proxy == nil {
+		return nil
+	}
+	if proxy.Server == "" {
+		return errors.New("proxy server must not be empty")
+	}
+	if !strings.Contains(proxy.Server, "://") {
+		return nil
+	}
+	u, err := url.Parse(proxy.Server)
+	if err != nil {
+		return fmt.Errorf("invalid proxy server %q: %w", proxy.Server, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks4", "socks5":
+		return nil
+	}
+	return fmt.Errorf("unsupported proxy server scheme %q: expected http, https, socks4 or socks5", u.Scheme)
+}
+
+var globEscapedChars = "$^+.*()|\\?{}[]"
+
+// globToRegex converts a Playwright URL glob into an anchored regular expression. Globs that don't form a valid
+// expression, e.g. because of an unterminated character class, only match themselves.
+func globToRegex(glob string) *regexp.Regexp {
+	tokens := []string{"^"}
+	inGroup := false
+	escape := func(c byte) string {
+		if strings.IndexByte(globEscapedChars, c) >= 0 {
+			return "\\" + string(c)
+		}
+		return string(c)
+	}
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		if c == '\\' && i+1 < len(glob) {
+			i++
+			tokens = append(tokens, escape(glob[i]))
+			continue
+		}
+		if c == '*' {
+			beforeDeep := i == 0 || glob[i-1] == '/'
+			starCount := 1
+			for i+1 < len(glob) && glob[i+1] == '*' {
+				starCount++
+				i++
+			}
+			afterDeep := i+1 == len(glob) || glob[i+1] == '/'
+			if starCount > 1 && beforeDeep && afterDeep {
+				tokens = append(tokens, `((?:[^/]*(?:/|$))*)`)
+				i++
+			} else {
+				tokens = append(tokens, `([^/]*)`)
+			}
+			continue
+		}
+		switch c {
+		case '?':
+			tokens = append(tokens, ".")
+		case '[', ']':
+			tokens = append(tokens, string(c))
+		case '{':
+			inGroup = true
+			tokens = append(tokens, "(")
+		case '}':
+			inGroup = false
+			tokens = append(tokens, ")")
+		case ',':
+			if inGroup {
+				tokens = append(tokens, "|")
+			} else {
+				tokens = append(tokens, ",")
+			}
+		default:
+			tokens = append(tokens, escape(c))
+		}
+	}
+	tokens = append(tokens, "$")
+	reg, err := regexp.Compile(strings.Join(tokens, ""))
+	if err != nil {
+		return regexp.MustCompile("^" + regexp.QuoteMeta(glob) + "$")
+	}
+	return reg
+}
+
 type routeHandlerEntry struct {
 	matcher *urlMatcher
 	handler routeHandler
@@ -244,13 +360,16 @@ func newRouteHandlerEntry(matcher *urlMatcher, handler routeHandler, times ...in
 	}
 }
 
-func prepareInterceptionPatterns(handlers []*routeHandlerEntry) []map[string]interface{} {
+func prepareInterceptionPatterns(handlers []*routeHandlerEntry) ([]map[string]interface{}, error) {
 	patterns := []map[string]interface{}{}
 	all := false
 	for _, h := range handlers {
 		switch h.matcher.urlOrPredicate.(type) {
 		case *regexp.Regexp:
-			pattern, flags := convertRegexp(h.matcher.urlOrPredicate.(*regexp.Regexp))
+			pattern, flags, err := convertRegexp(h.matcher.urlOrPredicate.(*regexp.Regexp))
+			if err != nil {
+				return nil, err
+			}
 			patterns = append(patterns, map[string]interface{}{
 				"regexSource": pattern,
 				"regexFlags":  flags,
@@ -268,9 +387,9 @@ func prepareInterceptionPatterns(handlers []*routeHandlerEntry) []map[string]int
 			{
 				"glob": "**/*",
 			},
-		}
+		}, nil
 	}
-	return patterns
+	return patterns, nil
 }
 
 type safeStringSet struct {
@@ -387,12 +506,20 @@ func newTimeoutSettings(parent *timeoutSettings) *timeoutSettings {
 }
 
 // SelectOptionValues is the option struct for ElementHandle.Select() etc.
+// All given fields are combined: an option is selected if it matches any of them. Options that are not matched are
+// deselected, so for a `<select multiple>` the result replaces the previous selection. For a single `<select>` only the
+// first match is selected.
 type SelectOptionValues struct {
+	// Options to select by value or label, whichever matches
 	ValuesOrLabels *[]string
-	Values         *[]string
-	Indexes        *[]int
-	Labels         *[]string
-	Elements       *[]ElementHandle
+	// Options to select by value
+	Values *[]string
+	// Options to select by index, zero based
+	Indexes *[]int
+	// Options to select by label
+	Labels *[]string
+	// Option elements to select
+	Elements *[]ElementHandle
 }
 
 func convertSelectOptionSet(values SelectOptionValues) map[string]interface{} {
@@ -550,14 +677,17 @@ type harRecordingMetadata struct {
 	Content *HarContentPolicy
 }
 
-func prepareRecordHarOptions(option recordHarInputOptions) recordHarOptions {
+func prepareRecordHarOptions(option recordHarInputOptions) (recordHarOptions, error) {
 	out := recordHarOptions{
 		Path: option.Path,
 	}
 	if option.URL != nil {
 		switch option.URL.(type) {
 		case *regexp.Regexp:
-			pattern, flags := convertRegexp(option.URL.(*regexp.Regexp))
+			pattern, flags, err := convertRegexp(option.URL.(*regexp.Regexp))
+			if err != nil {
+				return out, err
+			}
 			out.UrlRegexSource = String(pattern)
 			out.UrlRegexFlags = String(flags)
 		case string:
@@ -572,5 +702,5 @@ func prepareRecordHarOptions(option recordHarInputOptions) recordHarOptions {
 	} else if option.OmitContent != nil && *option.OmitContent {
 		out.Content = HarContentPolicyOmit
 	}
-	return out
+	return out, nil
 }
@@ -246,3 +246,55 @@ func TestAssignFields(t *testing.T) {
 		})
 	}
 }
+
+func TestURLMatches(t *testing.T) {
+	testCases := []struct {
+		baseURL string
+		pattern string
+		url     string
+		matches bool
+	}{
+		{"", "", "https://localhost/anything", true},
+		{"", "**/empty.html", "https://localhost:8080/empty.html", true},
+		{"", "**/empty.html", "https://localhost:8080/foo/empty.html", true},
+		{"", "**/*", "https://localhost:8080/foo/bar.js", true},
+		{"", "**/*.js", "https://localhost:8080/foo/bar.css", false},
+		{"", "https://localhost/*.js", "https://localhost/foo/bar.js", false},
+		{"", "https://localhost/*.js", "https://localhost/bar.js", true},
+		{"", "https://localhost/**/bar.js", "https://localhost/bar.js", true},
+		{"", "https://localhost/**/bar.js", "https://localhost/a/b/bar.js", true},
+		{"", "https://localhost/ba?.js", "https://localhost/baz.js", true},
+		{"", "https://localhost/ba?.js", "https://localhost/ba.js", false},
+		{"", "**/*.{png,jpg}", "https://localhost/a.jpg", true},
+		{"", "**/*.{png,jpg}", "https://localhost/a.gif", false},
+		{"", "**/a,b.html", "https://localhost/a,b.html", true},
+		{"", "**/a[0-9].html", "https://localhost/a1.html", true},
+		{"", `**/\?.html`, "https://localhost/?.html", true},
+		{"", `**/\?.html`, "https://localhost/a.html", false},
+		{"", "https://localhost/foo", "https://localhost/foo/bar", false},
+		{"https://localhost/app/", "login", "https://localhost/app/login", true},
+		{"https://localhost/app/", "/login", "https://localhost/login", true},
+		{"https://localhost/app", "login", "https://localhost/login", true},
+		{"https://localhost/app/", "https://example.com/login", "https://example.com/login", true},
+		{"https://localhost/app/", "**/login", "https://example.com/login", true},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.matches, URLMatches(tc.baseURL, tc.pattern, tc.url),
+			"URLMatches(%q, %q, %q)", tc.baseURL, tc.pattern, tc.url)
+	}
+}
+
+func TestGlobToRegexInvalidPatternMatchesLiterally(t *testing.T) {
+	require.True(t, globToRegex("https://localhost/[a").MatchString("https://localhost/[a"))
+	require.False(t, globToRegex("https://localhost/[a").MatchString("https://localhost/a"))
+}
+
+func TestValidateProxy(t *testing.T) {
+	require.NoError(t, validateProxy(nil))
+	for _, server := range []string{"myproxy.com:3128", "http://myproxy.com:3128", "https://myproxy.com", "socks5://127.0.0.1:1080", "socks4://127.0.0.1:1080"} {
+		require.NoError(t, validateProxy(&Proxy{Server: server}), server)
+	}
+	require.EqualError(t, validateProxy(&Proxy{}), "proxy server must not be empty")
+	require.EqualError(t, validateProxy(&Proxy{Server: "ftp://myproxy.com"}), `unsupported proxy server scheme "ftp": expected http, https, socks4 or socks5`)
+	require.ErrorContains(t, validateProxy(&Proxy{Server: "http://[::1"}), `invalid proxy server "http://[::1"`)
+}
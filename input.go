@@ -1,5 +1,28 @@
 package playwright
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidKeyboardModifier is returned by clicks, hovers and taps whose Modifiers contain anything other than
+// [KeyboardModifierAlt], [KeyboardModifierControl], [KeyboardModifierMeta] or [KeyboardModifierShift].
+var ErrInvalidKeyboardModifier = errors.New("invalid keyboard modifier")
+
+// validateKeyboardModifiers checks the modifiers of a message before it is sent, so a typo like "Ctrl" fails with a
+// clear error instead of the driver's protocol validation message.
+func validateKeyboardModifiers(params map[string]interface{}) error {
+	modifiers, _ := params["modifiers"].([]interface{})
+	for _, modifier := range modifiers {
+		switch modifier {
+		case *KeyboardModifierAlt, *KeyboardModifierControl, *KeyboardModifierMeta, *KeyboardModifierShift:
+		default:
+			return fmt.Errorf(`%w %q: expected one of "Alt", "Control", "Meta" or "Shift"`, ErrInvalidKeyboardModifier, modifier)
+		}
+	}
+	return nil
+}
+
 type mouseImpl struct {
 	channel *channel
 }
@@ -101,6 +124,31 @@ func (m *keyboardImpl) Press(key string, options ...KeyboardPressOptions) error
 	return err
 }
 
+func (m *keyboardImpl) PressWithModifiers(key string, modifiers []KeyboardModifier, options ...KeyboardPressOptions) error {
+	pressed := make([]string, 0, len(modifiers))
+	release := func() error {
+		var err error
+		for i := len(pressed) - 1; i >= 0; i-- {
+			if upErr := m.Up(pressed[i]); upErr != nil && err == nil {
+				err = upErr
+			}
+		}
+		return err
+	}
+	for _, modifier := range modifiers {
+		if err := m.Down(string(modifier)); err != nil {
+			_ = release()
+			return err
+		}
+		pressed = append(pressed, string(modifier))
+	}
+	if err := m.Press(key, options...); err != nil {
+		_ = release()
+		return err
+	}
+	return release()
+}
+
 type touchscreenImpl struct {
 	channel *channel
 }
@@ -111,7 +159,7 @@ func newTouchscreen(channel *channel) *touchscreenImpl {
 	}
 }
 
-func (t *touchscreenImpl) Tap(x int, y int) error {
+func (t *touchscreenImpl) Tap(x float64, y float64) error {
 	_, err := t.channel.Send("touchscreenTap", map[string]interface{}{"x": x, "y": y})
 	return err
 }
@@ -1,6 +1,7 @@
 package playwright
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -47,7 +48,7 @@ func (j *jsHandleImpl) EvaluateHandle(expression string, options ...interface{})
 	if channelOwner == nil {
 		return nil, nil
 	}
-	return channelOwner.(*jsHandleImpl), nil
+	return channelOwner.(JSHandle), nil
 }
 
 func (j *jsHandleImpl) GetProperty(name string) (JSHandle, error) {
@@ -57,7 +58,7 @@ func (j *jsHandleImpl) GetProperty(name string) (JSHandle, error) {
 	if err != nil {
 		return nil, err
 	}
-	return fromChannel(channel).(*jsHandleImpl), nil
+	return fromChannel(channel).(JSHandle), nil
 }
 
 func (j *jsHandleImpl) GetProperties() (map[string]JSHandle, error) {
@@ -68,7 +69,7 @@ func (j *jsHandleImpl) GetProperties() (map[string]JSHandle, error) {
 	propertiesMap := make(map[string]JSHandle)
 	for _, property := range properties.([]interface{}) {
 		item := property.(map[string]interface{})
-		propertiesMap[item["name"].(string)] = fromChannel(item["value"]).(*jsHandleImpl)
+		propertiesMap[item["name"].(string)] = fromChannel(item["value"]).(JSHandle)
 	}
 	return propertiesMap, nil
 }
@@ -94,6 +95,44 @@ func (j *jsHandleImpl) JSONValue() (interface{}, error) {
 	return parseResult(v), nil
 }
 
+func (j *jsHandleImpl) JSONValueAs(v interface{}) error {
+	value, err := j.JSONValue()
+	if err != nil {
+		return err
+	}
+	return convertJSONValue(value, v)
+}
+
+type evaluator interface {
+	Evaluate(expression string, arg ...interface{}) (interface{}, error)
+}
+
+// EvaluateAs evaluates the expression like [Page.Evaluate] and converts the result into T by round-tripping it through
+// JSON. It accepts anything with such an Evaluate method, e.g. [Page], [Frame], [JSHandle] or [ElementHandle].
+func EvaluateAs[T any](e evaluator, expression string, arg ...interface{}) (T, error) {
+	var out T
+	value, err := e.Evaluate(expression, arg...)
+	if err != nil {
+		return out, err
+	}
+	if v, ok := value.(T); ok {
+		return v, nil
+	}
+	err = convertJSONValue(value, &out)
+	return out, err
+}
+
+func convertJSONValue(value interface{}, v interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("could not marshal JSON value: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("could not unmarshal JSON value: %w", err)
+	}
+	return nil
+}
+
 func parseValue(result interface{}, refs map[float64]interface{}) interface{} {
 	vMap := result.(map[string]interface{})
 	if v, ok := vMap["n"]; ok {
@@ -204,7 +243,7 @@ func serializeValue(value interface{}, handles *[]*channel, depth int) interface
 				"v": "-Infinity",
 			}
 		}
-		if floatV == -0 {
+		if floatV == 0 && math.Signbit(floatV) {
 			return map[string]interface{}{
 				"v": "-0",
 			}
@@ -215,20 +254,31 @@ func serializeValue(value interface{}, handles *[]*channel, depth int) interface
 			}
 		}
 	}
-	if refV.Kind() == reflect.Slice {
-		aV := value.([]interface{})
-		for i := range aV {
-			aV[i] = serializeValue(aV[i], handles, depth+1)
+	if refV.Kind() == reflect.Ptr {
+		if refV.IsNil() {
+			return map[string]interface{}{
+				"v": "undefined",
+			}
+		}
+		return serializeValue(refV.Elem().Interface(), handles, depth+1)
+	}
+	// Slices, maps and structs may be of any element type, e.g. []ElementHandle, so they are walked with reflection
+	// and every element is serialized on its own. This way nested handles are sent as references too.
+	if refV.Kind() == reflect.Slice || refV.Kind() == reflect.Array {
+		aV := make([]interface{}, 0, refV.Len())
+		for i := 0; i < refV.Len(); i++ {
+			aV = append(aV, serializeValue(refV.Index(i).Interface(), handles, depth+1))
+		}
+		return map[string]interface{}{
+			"a": aV,
 		}
-		return aV
 	}
 	if refV.Kind() == reflect.Map {
 		out := []interface{}{}
-		vM := value.(map[string]interface{})
-		for key := range vM {
+		for _, key := range refV.MapKeys() {
 			out = append(out, map[string]interface{}{
-				"k": key,
-				"v": serializeValue(vM[key], handles, depth+1),
+				"k": fmt.Sprintf("%v", key.Interface()),
+				"v": serializeValue(refV.MapIndex(key).Interface(), handles, depth+1),
 			})
 		}
 		return map[string]interface{}{
@@ -253,12 +303,64 @@ func serializeValue(value interface{}, handles *[]*channel, depth int) interface
 			"b": v,
 		}
 	}
+	switch refV.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{
+			"n": refV.Int(),
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{
+			"n": refV.Uint(),
+		}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{
+			"n": refV.Float(),
+		}
+	case reflect.String:
+		return map[string]interface{}{
+			"s": refV.String(),
+		}
+	case reflect.Bool:
+		return map[string]interface{}{
+			"b": refV.Bool(),
+		}
+	case reflect.Struct:
+		return serializeStruct(refV, handles, depth)
+	}
 
 	return map[string]interface{}{
 		"v": "undefined",
 	}
 }
 
+// serializeStruct serializes the exported fields of a struct as an object, using the name from the json tag if any.
+func serializeStruct(refV reflect.Value, handles *[]*channel, depth int) interface{} {
+	out := []interface{}{}
+	for i := 0; i < refV.NumField(); i++ {
+		field := refV.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		out = append(out, map[string]interface{}{
+			"k": name,
+			"v": serializeValue(refV.Field(i).Interface(), handles, depth+1),
+		})
+	}
+	return map[string]interface{}{
+		"o": out,
+	}
+}
+
 func parseResult(result interface{}) interface{} {
 	return parseValue(result, map[float64]interface{}{})
 }
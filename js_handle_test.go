@@ -0,0 +1,65 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializeArgumentShouldHandleTypedCollections(t *testing.T) {
+	first := &elementHandleImpl{}
+	first.channel = &channel{guid: "first"}
+	second := &elementHandleImpl{}
+	second.channel = &channel{guid: "second"}
+
+	arg := serializeArgument([]ElementHandle{first, second}).(map[string]interface{})
+	require.Equal(t, map[string]interface{}{
+		"a": []interface{}{
+			map[string]interface{}{"h": 0},
+			map[string]interface{}{"h": 1},
+		},
+	}, arg["value"])
+	require.Equal(t, []*channel{first.channel, second.channel}, arg["handles"])
+
+	type nested struct {
+		Name    string        `json:"name"`
+		Handles []interface{} `json:"handles"`
+		Skipped string        `json:"-"`
+		private int
+	}
+	arg = serializeArgument(map[string]nested{
+		"key": {Name: "n", Handles: []interface{}{second, 1.5, int64(2)}},
+	}).(map[string]interface{})
+	require.Equal(t, map[string]interface{}{
+		"o": []interface{}{
+			map[string]interface{}{
+				"k": "key",
+				"v": map[string]interface{}{
+					"o": []interface{}{
+						map[string]interface{}{"k": "name", "v": map[string]interface{}{"s": "n"}},
+						map[string]interface{}{"k": "handles", "v": map[string]interface{}{
+							"a": []interface{}{
+								map[string]interface{}{"h": 0},
+								map[string]interface{}{"n": 1.5},
+								map[string]interface{}{"n": int64(2)},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}, arg["value"])
+	require.Equal(t, []*channel{second.channel}, arg["handles"])
+}
+
+func TestSerializeArgumentShouldNotModifyTheArgument(t *testing.T) {
+	arg := []interface{}{"a", 0.0}
+	value := serializeArgument(arg).(map[string]interface{})["value"]
+	require.Equal(t, []interface{}{"a", 0.0}, arg)
+	require.Equal(t, map[string]interface{}{
+		"a": []interface{}{
+			map[string]interface{}{"s": "a"},
+			map[string]interface{}{"n": 0.0},
+		},
+	}, value)
+}
@@ -0,0 +1,390 @@
+package playwright
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+var (
+	leadingRegexpFlags = regexp.MustCompile(`^\(\?([imsU]+)\)`)
+	// goOnlyRegexpSyntax matches constructs that are either spelled differently in JavaScript or change meaning
+	// there: inline flag groups after the start, named groups, Go escapes and POSIX classes.
+	goOnlyRegexpSyntax = regexp.MustCompile(`\(\?[imsU-]*[:)]|\(\?P<|\\[zApPQCx0-9]|\[:`)
+)
+
+// splitLeadingRegexpFlags separates a leading flag group like `(?i)` from the rest of a Go pattern.
+func splitLeadingRegexpFlags(pattern string) (flags, rest string) {
+	if matches := leadingRegexpFlags.FindStringSubmatch(pattern); matches != nil {
+		return matches[1], pattern[len(matches[0]):]
+	}
+	return "", pattern
+}
+
+// convertRegexp returns the JavaScript source and flags of a regular expression that matches like reg. Patterns
+// whose only flags are a leading `(?ims)` group are passed through with the flags moved to JavaScript flags.
+// Everything else, e.g. `(?s)` in the middle, `(?i:...)`, ungreedy `(?U)` or `\pL`, is translated from the parsed
+// expression, with the flags baked into the pattern since JavaScript has no inline flag groups.
+func convertRegexp(reg *regexp.Regexp) (pattern, flags string, err error) {
+	flags, pattern = splitLeadingRegexpFlags(reg.String())
+	if !strings.Contains(flags, "U") && !goOnlyRegexpSyntax.MatchString(pattern) {
+		return pattern, flags, nil
+	}
+	return translateRegexp(reg, &jsRegexpWriter{})
+}
+
+// convertRegexpIgnoringDiacritics is like convertRegexp, but every literal Latin letter also matches its accented
+// variants and may be followed by combining marks.
+func convertRegexpIgnoringDiacritics(reg *regexp.Regexp) (pattern, flags string, err error) {
+	return translateRegexp(reg, &jsRegexpWriter{ignoreDiacritics: true})
+}
+
+// translateRegexp writes the parsed reg with writer. It fails for expressions compiled with [regexp.CompilePOSIX]
+// that Perl syntax rejects, e.g. `[[:digit:]]**`.
+func translateRegexp(reg *regexp.Regexp, writer *jsRegexpWriter) (pattern, flags string, err error) {
+	parsed, err := syntax.Parse(reg.String(), syntax.Perl)
+	if err != nil {
+		return "", "", fmt.Errorf("could not convert regular expression to JavaScript: %w", err)
+	}
+	writer.write(parsed)
+	if writer.unicode {
+		return writer.String(), "u", nil
+	}
+	return writer.String(), "", nil
+}
+
+type jsRegexpWriter struct {
+	strings.Builder
+	// unicode is set when the pattern needs the JavaScript `u` flag for code points outside the BMP or `\p{...}`.
+	unicode bool
+	// ignoreDiacritics widens literal letters to their accented variants.
+	ignoreDiacritics bool
+}
+
+func (w *jsRegexpWriter) write(re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		w.WriteString(`[^\s\S]`)
+	case syntax.OpEmptyMatch:
+		w.WriteString(`(?:)`)
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			w.writeLiteral(r, re.Flags&syntax.FoldCase != 0)
+		}
+	case syntax.OpCharClass:
+		w.writeClass(re.Rune)
+	case syntax.OpAnyCharNotNL:
+		w.WriteString(`[^\n]`)
+	case syntax.OpAnyChar:
+		w.WriteString(`[\s\S]`)
+	case syntax.OpBeginLine:
+		w.WriteString(`(?<=^|\n)`)
+	case syntax.OpEndLine:
+		w.WriteString(`(?=\n|$)`)
+	case syntax.OpBeginText:
+		w.WriteString(`^`)
+	case syntax.OpEndText:
+		w.WriteString(`$`)
+	case syntax.OpWordBoundary:
+		w.WriteString(`\b`)
+	case syntax.OpNoWordBoundary:
+		w.WriteString(`\B`)
+	case syntax.OpCapture:
+		if re.Name != "" && isJSIdentifier(re.Name) {
+			w.WriteString("(?<" + re.Name + ">")
+		} else {
+			w.WriteString("(")
+		}
+		w.write(re.Sub[0])
+		w.WriteString(")")
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		w.writeAtom(re.Sub[0])
+		switch re.Op {
+		case syntax.OpStar:
+			w.WriteString("*")
+		case syntax.OpPlus:
+			w.WriteString("+")
+		case syntax.OpQuest:
+			w.WriteString("?")
+		default:
+			switch {
+			case re.Max == -1:
+				fmt.Fprintf(w, "{%d,}", re.Min)
+			case re.Min == re.Max:
+				fmt.Fprintf(w, "{%d}", re.Min)
+			default:
+				fmt.Fprintf(w, "{%d,%d}", re.Min, re.Max)
+			}
+		}
+		if re.Flags&syntax.NonGreedy != 0 {
+			w.WriteString("?")
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpAlternate {
+				w.WriteString("(?:")
+				w.write(sub)
+				w.WriteString(")")
+			} else {
+				w.write(sub)
+			}
+		}
+	case syntax.OpAlternate:
+		for i, sub := range re.Sub {
+			if i > 0 {
+				w.WriteString("|")
+			}
+			w.write(sub)
+		}
+	}
+}
+
+// writeAtom writes re so that a following quantifier applies to all of it.
+func (w *jsRegexpWriter) writeAtom(re *syntax.Regexp) {
+	switch {
+	case re.Op == syntax.OpLiteral && len(re.Rune) == 1 && !w.ignoreDiacritics,
+		re.Op == syntax.OpCharClass, re.Op == syntax.OpAnyChar, re.Op == syntax.OpAnyCharNotNL,
+		re.Op == syntax.OpCapture:
+		w.write(re)
+	default:
+		w.WriteString("(?:")
+		w.write(re)
+		w.WriteString(")")
+	}
+}
+
+func (w *jsRegexpWriter) writeLiteral(r rune, foldCase bool) {
+	variants := []rune{r}
+	if w.ignoreDiacritics {
+		if letters, ok := diacriticVariants()[r]; ok {
+			variants = letters
+		}
+	}
+	if foldCase {
+		for _, v := range variants {
+			for f := unicode.SimpleFold(v); f != v; f = unicode.SimpleFold(f) {
+				if !containsRune(variants, f) {
+					variants = append(variants, f)
+				}
+			}
+		}
+	}
+	if len(variants) > 1 {
+		w.WriteString("[")
+		for _, v := range variants {
+			w.writeRune(v, true)
+		}
+		w.WriteString("]")
+	} else {
+		w.writeRune(r, false)
+	}
+	if w.ignoreDiacritics && unicode.IsLetter(r) {
+		w.WriteString(`[\u0300-\u036F]*`)
+	}
+}
+
+func containsRune(runes []rune, r rune) bool {
+	for _, c := range runes {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// accentedLetters lists the accented forms of each lowercase letter in Latin-1 Supplement and Latin Extended-A.
+var accentedLetters = map[rune]string{
+	'a': "àáâãäåāăą",
+	'c': "çćĉċč",
+	'd': "ďđ",
+	'e': "èéêëēĕėęě",
+	'g': "ĝğġģ",
+	'h': "ĥħ",
+	'i': "ìíîïĩīĭį",
+	'j': "ĵ",
+	'k': "ķ",
+	'l': "ĺļľŀł",
+	'n': "ñńņň",
+	'o': "òóôõöøōŏő",
+	'r': "ŕŗř",
+	's': "śŝşš",
+	't': "ţťŧ",
+	'u': "ùúûüũūŭůűų",
+	'w': "ŵ",
+	'y': "ýÿŷ",
+	'z': "źżž",
+}
+
+var (
+	diacriticVariantsOnce sync.Once
+	diacriticVariantsMap  map[rune][]rune
+)
+
+// diacriticVariants maps every letter of accentedLetters, in both cases, to the letters sharing its base letter and
+// case.
+func diacriticVariants() map[rune][]rune {
+	diacriticVariantsOnce.Do(func() {
+		diacriticVariantsMap = make(map[rune][]rune)
+		for base, accented := range accentedLetters {
+			lower := append([]rune{base}, []rune(accented)...)
+			upper := make([]rune, 0, len(lower))
+			for _, r := range lower {
+				upper = append(upper, unicode.ToUpper(r))
+			}
+			for _, letters := range [][]rune{lower, upper} {
+				for _, r := range letters {
+					diacriticVariantsMap[r] = letters
+				}
+			}
+		}
+	})
+	return diacriticVariantsMap
+}
+
+// writeClass writes a character class given as sorted lo-hi pairs. Classes that reach the end of the Unicode range
+// are written negated, which keeps negations like [^a] short and avoids requiring the `u` flag.
+func (w *jsRegexpWriter) writeClass(ranges []rune) {
+	if property, ok := unicodePropertyOf(ranges); ok {
+		w.unicode = true
+		w.WriteString(`\p{` + property + `}`)
+		return
+	}
+	if len(ranges) >= 2 && ranges[len(ranges)-1] == unicode.MaxRune {
+		complement := make([]rune, 0, len(ranges))
+		next := rune(0)
+		for i := 0; i < len(ranges); i += 2 {
+			if ranges[i] > next {
+				complement = append(complement, next, ranges[i]-1)
+			}
+			next = ranges[i+1] + 1
+		}
+		if len(complement) == 0 {
+			w.WriteString(`[\s\S]`)
+			return
+		}
+		if property, ok := unicodePropertyOf(complement); ok {
+			w.unicode = true
+			w.WriteString(`\P{` + property + `}`)
+			return
+		}
+		w.WriteString("[^")
+		w.writeRanges(complement)
+		w.WriteString("]")
+		return
+	}
+	if len(ranges) == 0 {
+		w.WriteString(`[^\s\S]`)
+		return
+	}
+	w.WriteString("[")
+	w.writeRanges(ranges)
+	w.WriteString("]")
+}
+
+func (w *jsRegexpWriter) writeRanges(ranges []rune) {
+	for i := 0; i < len(ranges); i += 2 {
+		w.writeRune(ranges[i], true)
+		if ranges[i+1] != ranges[i] {
+			w.WriteString("-")
+			w.writeRune(ranges[i+1], true)
+		}
+	}
+}
+
+func (w *jsRegexpWriter) writeRune(r rune, inClass bool) {
+	special := `\^$.|?*+()[]{}/`
+	if inClass {
+		special = `\^]-[/`
+	}
+	switch {
+	case strings.ContainsRune(special, r):
+		w.WriteString(`\` + string(r))
+	case r == '\n':
+		w.WriteString(`\n`)
+	case r == '\r':
+		w.WriteString(`\r`)
+	case r == '\t':
+		w.WriteString(`\t`)
+	case r == '\f':
+		w.WriteString(`\f`)
+	case r == '\v':
+		w.WriteString(`\v`)
+	case r > 0xFFFF:
+		w.unicode = true
+		fmt.Fprintf(w, `\u{%X}`, r)
+	case r < 0x20 || !unicode.IsPrint(r):
+		fmt.Fprintf(w, `\u%04X`, r)
+	default:
+		w.WriteRune(r)
+	}
+}
+
+var (
+	unicodePropertiesOnce sync.Once
+	unicodeProperties     map[string]string
+)
+
+// unicodePropertyOf returns the JavaScript property escape name of a class that is exactly a Unicode category or
+// script, like the ones `\pL` or `\p{Greek}` produce, so they are not spelled out as thousands of ranges.
+func unicodePropertyOf(ranges []rune) (string, bool) {
+	unicodePropertiesOnce.Do(func() {
+		unicodeProperties = make(map[string]string)
+		for name, table := range unicode.Scripts {
+			unicodeProperties[rangeTableKey(table)] = "Script=" + name
+		}
+		// Categories win over scripts for the same set.
+		for name, table := range unicode.Categories {
+			unicodeProperties[rangeTableKey(table)] = name
+		}
+	})
+	property, ok := unicodeProperties[rangesKey(ranges)]
+	return property, ok
+}
+
+func rangeTableKey(table *unicode.RangeTable) string {
+	ranges := make([]rune, 0)
+	add := func(lo, hi rune) {
+		if n := len(ranges); n > 0 && ranges[n-1]+1 >= lo {
+			if hi > ranges[n-1] {
+				ranges[n-1] = hi
+			}
+			return
+		}
+		ranges = append(ranges, lo, hi)
+	}
+	for _, r := range table.R16 {
+		if r.Stride == 1 {
+			add(rune(r.Lo), rune(r.Hi))
+			continue
+		}
+		for c := rune(r.Lo); c <= rune(r.Hi); c += rune(r.Stride) {
+			add(c, c)
+		}
+	}
+	for _, r := range table.R32 {
+		if r.Stride == 1 {
+			add(rune(r.Lo), rune(r.Hi))
+			continue
+		}
+		for c := rune(r.Lo); c <= rune(r.Hi); c += rune(r.Stride) {
+			add(c, c)
+		}
+	}
+	return rangesKey(ranges)
+}
+
+func rangesKey(ranges []rune) string {
+	return fmt.Sprint(ranges)
+}
+
+func isJSIdentifier(name string) bool {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return name != ""
+}
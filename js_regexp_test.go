@@ -0,0 +1,61 @@
+package playwright
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertRegexp(t *testing.T) {
+	for _, tc := range []struct {
+		in, pattern, flags string
+	}{
+		{`hello`, `hello`, ``},
+		{`(?i)hello`, `hello`, `i`},
+		{`(?ims)^a.b$`, `^a.b$`, `ims`},
+		{`foo(?s).bar`, `foo[\s\S]bar`, ``},
+		{`foo.bar`, `foo.bar`, ``},
+		{`a(?i:b)c`, `a[Bb]c`, ``},
+		{`(?U)a+b`, `a+?b`, ``},
+		{`(?U)a+?b`, `a+b`, ``},
+		{`x(?m)^y$`, `x(?<=^|\n)y(?=\n|$)`, ``},
+		{`(?P<year>\d{4})-\d+`, `(?<year>[0-9]{4})-[0-9]+`, ``},
+		{`\Ahello\z`, `^hello$`, ``},
+		{`[^a]b`, `[^a]b`, ``},
+		{`\x{1F600}`, `\u{1F600}`, `u`},
+		{`\pL\PL\p{Greek}`, `\p{L}\P{L}\p{Script=Greek}`, `u`},
+		{`[[:digit:]]+/(ab|c)`, `[0-9]+\/(ab|c)`, ``},
+	} {
+		pattern, flags, err := convertRegexp(regexp.MustCompile(tc.in))
+		require.NoError(t, err)
+		require.Equal(t, tc.pattern, pattern, tc.in)
+		require.Equal(t, tc.flags, flags, tc.in)
+	}
+}
+
+func TestConvertRegexpIgnoringDiacritics(t *testing.T) {
+	marks := `[\u0300-\u036F]*`
+	for _, tc := range []struct {
+		in, pattern string
+	}{
+		{`cafe`, `[cçćĉċč]` + marks + `[aàáâãäåāăą]` + marks + `f` + marks + `[eèéêëēĕėęě]` + marks},
+		{`É1`, `[EÈÉÊËĒĔĖĘĚ]` + marks + `1`},
+		{`é+`, `(?:[eèéêëēĕėęě]` + marks + `)+`},
+		{`(?i)ñ`, `[NÑŃŅŇnñńņň]` + marks},
+		{`[a-c]`, `[a-c]`},
+	} {
+		pattern, flags, err := convertRegexpIgnoringDiacritics(regexp.MustCompile(tc.in))
+		require.NoError(t, err)
+		require.Equal(t, tc.pattern, pattern, tc.in)
+		require.Equal(t, "", flags, tc.in)
+	}
+}
+
+func TestConvertRegexpShouldReportUntranslatableExpressions(t *testing.T) {
+	// POSIX syntax allows stacked repetitions, which Perl syntax, and so the translation, rejects.
+	_, _, err := convertRegexp(regexp.MustCompilePOSIX(`[[:digit:]]**`))
+	require.ErrorContains(t, err, "invalid nested repetition operator")
+	_, _, err = convertRegexpIgnoringDiacritics(regexp.MustCompilePOSIX(`a**`))
+	require.ErrorContains(t, err, "invalid nested repetition operator")
+}
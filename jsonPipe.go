@@ -2,7 +2,6 @@ package playwright
 
 import (
 	"encoding/json"
-	"log"
 )
 
 type jsonPipe struct {
@@ -25,12 +24,14 @@ func newJsonPipe(parent *channelOwner, objectType string, guid string, initializ
 	j.channel.On("message", func(ev map[string]interface{}) {
 		m, err := json.Marshal(ev["message"])
 		if err != nil {
-			log.Fatal(err)
+			j.connection.logger.Errorf("could not encode JsonPipe message: %v", err)
+			return
 		}
 		var msg message
 		err = json.Unmarshal(m, &msg)
 		if err != nil {
-			log.Fatal(err)
+			j.connection.logger.Errorf("could not decode JsonPipe message: %v", err)
+			return
 		}
 		j.Emit("message", &msg)
 	})
@@ -3,16 +3,31 @@ package playwright
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 
 	"github.com/playwright-community/playwright-go/internal/multierror"
 )
 
+const defaultTestIdAttributeName = "data-testid"
+
 var (
-	testIdAttributeName    = "data-testid"
 	ErrLocatorNotSameFrame = errors.New("inner 'has' or 'hasNot' locator must belong to the same frame")
 )
 
+// LocatorPressSequentiallyOptions are the options of [Locator.PressSequentially].
+type LocatorPressSequentiallyOptions struct {
+	// Time to wait between key presses in milliseconds. Defaults to 0.
+	Delay *float64 `json:"delay"`
+	// Actions that initiate navigations are waiting for these navigations to happen and for pages to start loading. You
+	// can opt out of waiting via setting this flag. You would only need this option in the exceptional cases such as
+	// navigating to inaccessible pages. Defaults to `false`.
+	NoWaitAfter *bool `json:"noWaitAfter"`
+	// Maximum time in milliseconds. Defaults to `30000` (30 seconds). Pass `0` to disable timeout. The default value can
+	// be changed by using the [BrowserContext.SetDefaultTimeout] or [Page.SetDefaultTimeout] methods.
+	Timeout *float64 `json:"timeout"`
+}
+
 type locatorImpl struct {
 	frame    *frameImpl
 	selector string
@@ -26,11 +41,28 @@ func newLocator(frame *frameImpl, selector string, options ...LocatorLocatorOpti
 		option = &options[0]
 	}
 	locator := &locatorImpl{frame: frame, selector: selector, options: option, err: nil}
-	if option.HasText != nil {
-		selector += fmt.Sprintf(` >> internal:has-text=%s`, escapeForTextSelector(option.HasText, false))
+	hasText, hasNotText := option.HasText, option.HasNotText
+	if option.NormalizeWhitespace != nil && *option.NormalizeWhitespace {
+		if reg, ok := hasText.(*regexp.Regexp); ok {
+			hasText = normalizeWhitespaceRegexp(reg)
+		}
+		if reg, ok := hasNotText.(*regexp.Regexp); ok {
+			hasNotText = normalizeWhitespaceRegexp(reg)
+		}
 	}
-	if option.HasNotText != nil {
-		selector += fmt.Sprintf(` >> internal:has-not-text=%s`, escapeForTextSelector(option.HasText, false))
+	if hasText != nil {
+		if escaped, err := escapeForTextSelector(hasText, false); err != nil {
+			locator.err = multierror.Join(locator.err, err)
+		} else {
+			selector += fmt.Sprintf(` >> internal:has-text=%s`, escaped)
+		}
+	}
+	if hasNotText != nil {
+		if escaped, err := escapeForTextSelector(hasNotText, false); err != nil {
+			locator.err = multierror.Join(locator.err, err)
+		} else {
+			selector += fmt.Sprintf(` >> internal:has-not-text=%s`, escaped)
+		}
 	}
 	if option.Has != nil {
 		has := option.Has.(*locatorImpl)
@@ -57,6 +89,10 @@ func (l *locatorImpl) Err() error {
 	return l.err
 }
 
+func (l *locatorImpl) ResolvedSelector() string {
+	return l.selector
+}
+
 func (l *locatorImpl) All() ([]Locator, error) {
 	result := make([]Locator, 0)
 	count, err := l.Count()
@@ -328,7 +364,7 @@ func (l *locatorImpl) Filter(options ...LocatorFilterOptions) Locator {
 }
 
 func (l *locatorImpl) First() Locator {
-	return newLocator(l.frame, l.selector+" >> nth=0")
+	return l.Nth(0)
 }
 
 func (l *locatorImpl) Focus(options ...LocatorFocusOptions) error {
@@ -350,21 +386,31 @@ func (l *locatorImpl) FrameLocator(selector string) FrameLocator {
 	return newFrameLocator(l.frame, l.selector+" >> "+selector)
 }
 
-func (l *locatorImpl) GetAttribute(name string, options ...LocatorGetAttributeOptions) (string, error) {
+func (l *locatorImpl) GetAttribute(name string, options ...LocatorGetAttributeOptions) (*string, error) {
 	if l.err != nil {
-		return "", l.err
+		return nil, l.err
 	}
 	opt := FrameGetAttributeOptions{
 		Strict: Bool(true),
 	}
 	if len(options) == 1 {
 		if err := assignStructFields(&opt, options[0], false); err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 	return l.frame.GetAttribute(l.selector, name, opt)
 }
 
+// getByLocator is like [frameImpl.getByLocator], for selectors chained to l.
+func (l *locatorImpl) getByLocator(selector string, err error) Locator {
+	if err != nil {
+		locator := newLocator(l.frame, l.selector)
+		locator.err = multierror.Join(l.err, err)
+		return locator
+	}
+	return l.Locator(selector)
+}
+
 func (l *locatorImpl) GetByAltText(text interface{}, options ...LocatorGetByAltTextOptions) Locator {
 	exact := false
 	if len(options) == 1 {
@@ -372,7 +418,7 @@ func (l *locatorImpl) GetByAltText(text interface{}, options ...LocatorGetByAltT
 			exact = true
 		}
 	}
-	return l.Locator(getByAltTextSelector(text, exact))
+	return l.getByLocator(getByAltTextSelector(text, exact))
 }
 
 func (l *locatorImpl) GetByLabel(text interface{}, options ...LocatorGetByLabelOptions) Locator {
@@ -382,7 +428,7 @@ func (l *locatorImpl) GetByLabel(text interface{}, options ...LocatorGetByLabelO
 			exact = true
 		}
 	}
-	return l.Locator(getByLabelSelector(text, exact))
+	return l.getByLocator(getByLabelSelector(text, exact))
 }
 
 func (l *locatorImpl) GetByPlaceholder(text interface{}, options ...LocatorGetByPlaceholderOptions) Locator {
@@ -392,25 +438,24 @@ func (l *locatorImpl) GetByPlaceholder(text interface{}, options ...LocatorGetBy
 			exact = true
 		}
 	}
-	return l.Locator(getByPlaceholderSelector(text, exact))
+	return l.getByLocator(getByPlaceholderSelector(text, exact))
 }
 
 func (l *locatorImpl) GetByRole(role AriaRole, options ...LocatorGetByRoleOptions) Locator {
-	return l.Locator(getByRoleSelector(role, options...))
+	return l.getByLocator(getByRoleSelector(role, options...))
 }
 
 func (l *locatorImpl) GetByTestId(testId interface{}) Locator {
-	return l.Locator(getByTestIdSelector(getTestIdAttributeName(), testId))
+	return l.getByLocator(getByTestIdSelector(l.frame.connection.testIdAttributeName(), testId))
 }
 
 func (l *locatorImpl) GetByText(text interface{}, options ...LocatorGetByTextOptions) Locator {
-	exact := false
+	exact, ignoreDiacritics := false, false
 	if len(options) == 1 {
-		if *options[0].Exact {
-			exact = true
-		}
+		exact = options[0].Exact != nil && *options[0].Exact
+		ignoreDiacritics = options[0].IgnoreDiacritics != nil && *options[0].IgnoreDiacritics
 	}
-	return l.Locator(getByTextSelector(text, exact))
+	return l.getByLocator(getByTextSelector(text, exact, ignoreDiacritics))
 }
 
 func (l *locatorImpl) GetByTitle(text interface{}, options ...LocatorGetByTitleOptions) Locator {
@@ -420,7 +465,7 @@ func (l *locatorImpl) GetByTitle(text interface{}, options ...LocatorGetByTitleO
 			exact = true
 		}
 	}
-	return l.Locator(getByTitleSelector(text, exact))
+	return l.getByLocator(getByTitleSelector(text, exact))
 }
 
 func (l *locatorImpl) Highlight() error {
@@ -581,31 +626,36 @@ func (l *locatorImpl) IsVisible(options ...LocatorIsVisibleOptions) (bool, error
 }
 
 func (l *locatorImpl) Last() Locator {
-	return newLocator(l.frame, l.selector+" >> nth=-1")
+	return l.Nth(-1)
 }
 
 func (l *locatorImpl) Locator(selectorOrLocator interface{}, options ...LocatorLocatorOptions) Locator {
-	selector, ok := selectorOrLocator.(string)
-	if ok {
-		return newLocator(l.frame, l.selector+" >> "+selector, options...)
-	}
-	locator, ok := selectorOrLocator.(*locatorImpl)
-	if ok {
-		if l.frame != locator.frame {
-			l.err = multierror.Join(l.err, ErrLocatorNotSameFrame)
-			return l
+	var locator *locatorImpl
+	switch v := selectorOrLocator.(type) {
+	case string:
+		locator = newLocator(l.frame, l.selector+" >> "+v, options...)
+	case *locatorImpl:
+		if l.frame != v.frame {
+			locator = newLocator(l.frame, l.selector)
+			locator.err = ErrLocatorNotSameFrame
+		} else {
+			locator = newLocator(l.frame, l.selector+" >> internal:chain="+escapeText(v.selector), options...)
 		}
-		return newLocator(l.frame,
-			l.selector+" >> internal:chain="+escapeText(locator.selector),
-			options...,
-		)
+	default:
+		locator = newLocator(l.frame, l.selector)
+		locator.err = fmt.Errorf("invalid locator parameter: %v", selectorOrLocator)
 	}
-	l.err = multierror.Join(l.err, fmt.Errorf("invalid locator parameter: %v", selectorOrLocator))
-	return l
+	// errors of this locator are kept, the receiver itself is never modified
+	if l.err != nil {
+		locator.err = multierror.Join(l.err, locator.err)
+	}
+	return locator
 }
 
 func (l *locatorImpl) Nth(index int) Locator {
-	return newLocator(l.frame, l.selector+" >> nth="+strconv.Itoa(index))
+	locator := newLocator(l.frame, l.selector+" >> nth="+strconv.Itoa(index))
+	locator.err = l.err
+	return locator
 }
 
 func (l *locatorImpl) Page() (Page, error) {
@@ -769,6 +819,14 @@ func (l *locatorImpl) TextContent(options ...LocatorTextContentOptions) (string,
 	return l.frame.TextContent(l.selector, opt)
 }
 
+func (l *locatorImpl) PressSequentially(text string, options ...LocatorPressSequentiallyOptions) error {
+	var option LocatorTypeOptions
+	if len(options) == 1 {
+		option = LocatorTypeOptions(options[0])
+	}
+	return l.Type(text, option)
+}
+
 func (l *locatorImpl) Type(text string, options ...LocatorTypeOptions) error {
 	if l.err != nil {
 		return l.err
@@ -811,7 +869,11 @@ func (l *locatorImpl) WaitFor(options ...LocatorWaitForOptions) error {
 			return err
 		}
 	}
-	_, err := l.frame.WaitForSelector(l.selector, opt)
+	// the matched element is not needed, so no handle is created for it
+	_, err := l.frame.channel.Send("waitForSelector", map[string]interface{}{
+		"selector":        l.selector,
+		"omitReturnValue": true,
+	}, opt)
 	return err
 }
 
@@ -822,10 +884,20 @@ func (l *locatorImpl) withElement(
 	if l.err != nil {
 		return nil, l.err
 	}
-	handle, err := l.frame.WaitForSelector(l.selector, options...)
+	option := FrameWaitForSelectorOptions{
+		State:  WaitForSelectorStateAttached,
+		Strict: Bool(true),
+	}
+	if len(options) == 1 {
+		option.Timeout = options[0].Timeout
+	}
+	handle, err := l.frame.WaitForSelector(l.selector, option)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		_ = handle.Dispose()
+	}()
 
 	result, err := callback(handle)
 	if err != nil {
@@ -4,19 +4,58 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
-func convertRegexp(reg *regexp.Regexp) (pattern, flags string) {
-	matches := regexp.MustCompile(`\(\?([imsU]+)\)(.+)`).FindStringSubmatch(reg.String())
-
-	if len(matches) == 3 {
-		pattern = matches[2]
-		flags = matches[1]
-	} else {
-		pattern = reg.String()
+// normalizeWhitespaceRegexp rewrites every run of literal whitespace outside of character classes into `\s+`
+func normalizeWhitespaceRegexp(reg *regexp.Regexp) *regexp.Regexp {
+	// This works on the Go pattern, which is compiled again below.
+	flags, pattern := splitLeadingRegexpFlags(reg.String())
+	runes := []rune(pattern)
+	isSpace := func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	}
+	builder := &strings.Builder{}
+	inClass, escaped := false, false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if !escaped && !inClass && isSpace(r) {
+			end := i
+			for end+1 < len(runes) && isSpace(runes[end+1]) {
+				end++
+			}
+			// keep whitespace that is the operand of a quantifier as is
+			if end+1 < len(runes) && strings.ContainsRune("*+?{", runes[end+1]) {
+				builder.WriteString(string(runes[i : end+1]))
+			} else {
+				builder.WriteString(`\s+`)
+			}
+			i = end
+			continue
+		}
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '[':
+			inClass = true
+		case r == ']':
+			inClass = false
+		}
+		builder.WriteRune(r)
+	}
+	normalized := builder.String()
+	if flags != "" {
+		normalized = fmt.Sprintf("(?%s)%s", flags, normalized)
+	}
+	compiled, err := regexp.Compile(normalized)
+	if err != nil {
+		// a rewrite this scan did not foresee must not turn a valid pattern into a panic
+		return reg
 	}
-	return
+	return compiled
 }
 
 func escapeForAttributeSelector(value string, exact bool) string {
@@ -27,16 +66,19 @@ func escapeForAttributeSelector(value string, exact bool) string {
 	return fmt.Sprintf(`"%s"%s`, strings.Replace(strings.Replace(value, `\`, `\\`, -1), `"`, `\"`, -1), suffix)
 }
 
-func escapeForTextSelector(text interface{}, exact bool) string {
+func escapeForTextSelector(text interface{}, exact bool) (string, error) {
 	switch text := text.(type) {
 	case *regexp.Regexp:
-		pattern, flag := convertRegexp(text)
-		return fmt.Sprintf(`/%s/%s`, pattern, flag)
+		pattern, flag, err := convertRegexp(text)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(`/%s/%s`, pattern, flag), nil
 	default:
 		if exact {
-			return fmt.Sprintf(`%ss`, escapeText(text.(string)))
+			return fmt.Sprintf(`%ss`, escapeText(text.(string))), nil
 		}
-		return fmt.Sprintf(`%si`, escapeText(text.(string)))
+		return fmt.Sprintf(`%si`, escapeText(text.(string))), nil
 	}
 }
 
@@ -48,95 +90,118 @@ func escapeText(s string) string {
 	return strings.TrimSpace(builder.String())
 }
 
-func getByAltTextSelector(text interface{}, exact bool) string {
+func getByAltTextSelector(text interface{}, exact bool) (string, error) {
 	return getByAttributeTextSelector("alt", text, exact)
 }
 
-func getByAttributeTextSelector(attrName string, text interface{}, exact bool) string {
+func getByAttributeTextSelector(attrName string, text interface{}, exact bool) (string, error) {
 	switch text := text.(type) {
 	case *regexp.Regexp:
-		pattern, flag := convertRegexp(text)
-		return fmt.Sprintf(`internal:attr=[%s=/%s/%s]`, attrName, pattern, flag)
+		pattern, flag, err := convertRegexp(text)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(`internal:attr=[%s=/%s/%s]`, attrName, pattern, flag), nil
 	default:
-		return fmt.Sprintf(`internal:attr=[%s=%s]`, attrName, escapeForAttributeSelector(text.(string), exact))
+		return fmt.Sprintf(`internal:attr=[%s=%s]`, attrName, escapeForAttributeSelector(text.(string), exact)), nil
 	}
 }
 
-func getByLabelSelector(text interface{}, exact bool) string {
-	return fmt.Sprintf(`internal:label=%s`, escapeForTextSelector(text, exact))
+func getByLabelSelector(text interface{}, exact bool) (string, error) {
+	escaped, err := escapeForTextSelector(text, exact)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`internal:label=%s`, escaped), nil
 }
 
-func getByPlaceholderSelector(text interface{}, exact bool) string {
+func getByPlaceholderSelector(text interface{}, exact bool) (string, error) {
 	return getByAttributeTextSelector("placeholder", text, exact)
 }
 
-func getByRoleSelector(role AriaRole, options ...LocatorGetByRoleOptions) string {
-	props := make(map[string]string)
-	if len(options) == 1 {
-		if options[0].Checked != nil {
-			props["checked"] = fmt.Sprintf("%v", options[0].Checked)
-		}
-		if options[0].Disabled != nil {
-			props["disabled"] = fmt.Sprintf("%v", options[0].Disabled)
-		}
-		if options[0].Selected != nil {
-			props["selected"] = fmt.Sprintf("%v", options[0].Selected)
-		}
-		if options[0].Expanded != nil {
-			props["expanded"] = fmt.Sprintf("%v", options[0].Expanded)
-		}
-		if options[0].IncludeHidden != nil {
-			props["includeHidden"] = fmt.Sprintf("%v", options[0].IncludeHidden)
+func getByRoleSelector(role AriaRole, options ...LocatorGetByRoleOptions) (string, error) {
+	// Properties are emitted in a fixed order, so the same options always produce the same selector.
+	props := make([][2]string, 0)
+	addBool := func(name string, value *bool) {
+		if value != nil {
+			props = append(props, [2]string{name, strconv.FormatBool(*value)})
 		}
+	}
+	if len(options) == 1 {
+		addBool("checked", options[0].Checked)
+		addBool("disabled", options[0].Disabled)
+		addBool("selected", options[0].Selected)
+		addBool("expanded", options[0].Expanded)
+		addBool("include-hidden", options[0].IncludeHidden)
 		if options[0].Level != nil {
-			props["level"] = fmt.Sprintf("%d", options[0].Level)
+			props = append(props, [2]string{"level", strconv.Itoa(*options[0].Level)})
 		}
 		if options[0].Name != nil {
-			exact := false
-			if options[0].Exact != nil {
-				exact = *options[0].Exact
-			}
-			switch options[0].Name.(type) {
+			exact := options[0].Exact != nil && *options[0].Exact
+			switch name := options[0].Name.(type) {
 			case string:
-				props["name"] = escapeForAttributeSelector(options[0].Name.(string), exact)
+				if options[0].NormalizeWhitespace != nil && *options[0].NormalizeWhitespace {
+					name = strings.Join(strings.Fields(name), " ")
+				}
+				props = append(props, [2]string{"name", escapeForAttributeSelector(name, exact)})
 			case *regexp.Regexp:
-				pattern, flag := convertRegexp(options[0].Name.(*regexp.Regexp))
-				props["name"] = fmt.Sprintf(`/%s/%s`, pattern, flag)
+				pattern, flag, err := convertRegexp(name)
+				if err != nil {
+					return "", err
+				}
+				props = append(props, [2]string{"name", fmt.Sprintf(`/%s/%s`, pattern, flag)})
 			}
 		}
-		if options[0].Pressed != nil {
-			props["pressed"] = fmt.Sprintf("%v", options[0].Pressed)
-		}
+		addBool("pressed", options[0].Pressed)
 	}
 	propsStr := ""
-	for k, v := range props {
-		propsStr += "[" + k + "=" + v + "]"
+	for _, prop := range props {
+		propsStr += "[" + prop[0] + "=" + prop[1] + "]"
 	}
-	return fmt.Sprintf("internal:role=%s%s", role, propsStr)
+	return fmt.Sprintf("internal:role=%s%s", role, propsStr), nil
 }
 
-func getByTextSelector(text interface{}, exact bool) string {
-	return fmt.Sprintf(`internal:text=%s`, escapeForTextSelector(text, exact))
+func getByTextSelector(text interface{}, exact, ignoreDiacritics bool) (string, error) {
+	if ignoreDiacritics {
+		pattern, flag, err := convertRegexpIgnoringDiacritics(textRegexp(text, exact))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(`internal:text=/%s/%s`, pattern, flag), nil
+	}
+	escaped, err := escapeForTextSelector(text, exact)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`internal:text=%s`, escaped), nil
 }
 
-func getByTestIdSelector(testIdAttributeName string, testId interface{}) string {
+// textRegexp returns a regular expression that matches like the text selector for text would: a string matches
+// case-insensitively as a substring, or exactly as the whole whitespace-normalized text.
+func textRegexp(text interface{}, exact bool) *regexp.Regexp {
+	if reg, ok := text.(*regexp.Regexp); ok {
+		return reg
+	}
+	quoted := regexp.QuoteMeta(strings.Join(strings.Fields(text.(string)), " "))
+	if exact {
+		return regexp.MustCompile(`^` + quoted + `$`)
+	}
+	return regexp.MustCompile(`(?i)` + quoted)
+}
+
+func getByTestIdSelector(testIdAttributeName string, testId interface{}) (string, error) {
 	switch testId := testId.(type) {
 	case *regexp.Regexp:
-		pattern, flag := convertRegexp(testId)
-		return fmt.Sprintf(`internal:testid=[%s=/%s/%s]`, testIdAttributeName, pattern, flag)
+		pattern, flag, err := convertRegexp(testId)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(`internal:testid=[%s=/%s/%s]`, testIdAttributeName, pattern, flag), nil
 	default:
-		return fmt.Sprintf(`internal:testid=[%s=%s]`, testIdAttributeName, escapeForAttributeSelector(testId.(string), true))
+		return fmt.Sprintf(`internal:testid=[%s=%s]`, testIdAttributeName, escapeForAttributeSelector(testId.(string), true)), nil
 	}
 }
 
-func getByTitleSelector(text interface{}, exact bool) string {
+func getByTitleSelector(text interface{}, exact bool) (string, error) {
 	return getByAttributeTextSelector("title", text, exact)
 }
-
-func getTestIdAttributeName() string {
-	return testIdAttributeName
-}
-
-func setTestIdAttributeName(name string) {
-	testIdAttributeName = name
-}
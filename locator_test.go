@@ -0,0 +1,100 @@
+package playwright
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocatorNthShouldComposeSelectors(t *testing.T) {
+	locator := newLocator(nil, "tr")
+	require.Equal(t, "tr >> nth=0", locator.First().(*locatorImpl).selector)
+	require.Equal(t, "tr >> nth=-1", locator.Last().(*locatorImpl).selector)
+	require.Equal(t, "tr >> nth=2", locator.Nth(2).(*locatorImpl).selector)
+	require.Equal(t, "tr >> nth=-2", locator.Nth(-2).(*locatorImpl).selector)
+	require.Equal(t, "tr >> nth=-1 >> nth=0", locator.Last().First().(*locatorImpl).selector)
+}
+
+func TestLocatorNthShouldKeepErrors(t *testing.T) {
+	locator := newLocator(nil, "tr")
+	locator.err = errors.New("invalid locator")
+	require.ErrorIs(t, locator.First().(*locatorImpl).err, locator.err)
+	require.ErrorIs(t, locator.Last().(*locatorImpl).err, locator.err)
+	require.ErrorIs(t, locator.Nth(-3).(*locatorImpl).err, locator.err)
+}
+
+func TestLocatorLocatorShouldNotModifyTheReceiver(t *testing.T) {
+	frame, otherFrame := &frameImpl{}, &frameImpl{}
+	row := newLocator(frame, "tr")
+
+	cell := row.Locator("td", LocatorLocatorOptions{HasText: "total"}).(*locatorImpl)
+	require.NoError(t, cell.err)
+	require.Equal(t, `tr >> td >> internal:has-text="total"i`, cell.selector)
+
+	chained := row.Locator(newLocator(frame, "td")).(*locatorImpl)
+	require.NoError(t, chained.err)
+	require.Equal(t, `tr >> internal:chain="td"`, chained.selector)
+
+	invalid := row.Locator(newLocator(otherFrame, "td")).(*locatorImpl)
+	require.ErrorIs(t, invalid.err, ErrLocatorNotSameFrame)
+	invalid = row.Locator(42).(*locatorImpl)
+	require.EqualError(t, invalid.err, "invalid locator parameter: 42")
+	require.NoError(t, row.err)
+
+	// errors of the parent are kept by the children
+	require.ErrorIs(t, invalid.Locator("span").(*locatorImpl).err, invalid.err)
+}
+
+func TestGetByRoleSelector(t *testing.T) {
+	roleSelector := func(role AriaRole, options ...LocatorGetByRoleOptions) string {
+		selector, err := getByRoleSelector(role, options...)
+		require.NoError(t, err)
+		return selector
+	}
+	require.Equal(t, `internal:role=button`, roleSelector("button"))
+	require.Equal(t,
+		`internal:role=checkbox[checked=true][disabled=false][include-hidden=true][level=2][name="Hello"s]`,
+		roleSelector("checkbox", LocatorGetByRoleOptions{
+			Checked:       Bool(true),
+			Disabled:      Bool(false),
+			IncludeHidden: Bool(true),
+			Level:         Int(2),
+			Name:          "Hello",
+			Exact:         Bool(true),
+		}))
+	require.Equal(t, "internal:role=button[name=\"  Hello\n  World \"i]", roleSelector("button", LocatorGetByRoleOptions{
+		Name: "  Hello\n  World ",
+	}))
+	require.Equal(t, `internal:role=button[name="Hello World"s]`, roleSelector("button", LocatorGetByRoleOptions{
+		Name:                "  Hello\n  World ",
+		Exact:               Bool(true),
+		NormalizeWhitespace: Bool(true),
+	}))
+}
+
+func TestLocatorGetByShouldKeepRegexpConversionErrors(t *testing.T) {
+	reg := regexp.MustCompilePOSIX(`[[:digit:]]**`)
+	locator := newLocator(nil, "tr")
+	require.ErrorContains(t, locator.GetByText(reg).(*locatorImpl).err, "invalid nested repetition operator")
+	require.ErrorContains(t, locator.GetByRole("cell", LocatorGetByRoleOptions{Name: reg}).(*locatorImpl).err, "invalid nested repetition operator")
+	require.ErrorContains(t, newLocator(nil, "tr", LocatorLocatorOptions{HasText: reg}).err, "invalid nested repetition operator")
+}
+
+func TestLocatorClickShouldValidateModifiers(t *testing.T) {
+	conn := newConnection(func() error { return nil })
+	conn.onmessage = func(msg map[string]interface{}) error {
+		t.Errorf("%s was sent with invalid modifiers", msg["method"])
+		return nil
+	}
+	frame := &frameImpl{}
+	frame.channel = newChannel(conn, "frame")
+	locator := newLocator(frame, "a")
+	err := locator.Click(LocatorClickOptions{
+		Modifiers: []KeyboardModifier{*KeyboardModifierShift, "Ctrl"},
+	})
+	require.ErrorIs(t, err, ErrInvalidKeyboardModifier)
+	require.ErrorContains(t, err, `"Ctrl"`)
+	require.ErrorIs(t, locator.Tap(LocatorTapOptions{Modifiers: []KeyboardModifier{"shift"}}), ErrInvalidKeyboardModifier)
+}
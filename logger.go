@@ -0,0 +1,88 @@
+package playwright
+
+import (
+	"fmt"
+	"log"
+)
+
+// Logger receives the messages logged by playwright-go, e.g. failures in event handlers that have no caller to
+// return an error to. Implement it to route them through a structured logger such as zap or slog.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// LogLevel is the minimum level of the messages passed to a [Logger].
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+	// LogLevelOff disables logging.
+	LogLevelOff
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	case LogLevelOff:
+		return "OFF"
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// stdLogger writes to the standard library logger, which is what playwright-go used before loggers were pluggable.
+type stdLogger struct{}
+
+func (stdLogger) Debugf(format string, args ...interface{}) { log.Printf(format, args...) }
+func (stdLogger) Infof(format string, args ...interface{})  { log.Printf(format, args...) }
+func (stdLogger) Warnf(format string, args ...interface{})  { log.Printf(format, args...) }
+func (stdLogger) Errorf(format string, args ...interface{}) { log.Printf(format, args...) }
+
+// leveledLogger drops the messages below its level before they reach the wrapped logger.
+type leveledLogger struct {
+	logger Logger
+	level  LogLevel
+}
+
+func newLeveledLogger(logger Logger, level LogLevel) *leveledLogger {
+	if logger == nil {
+		logger = stdLogger{}
+	}
+	return &leveledLogger{logger: logger, level: level}
+}
+
+func (l *leveledLogger) Debugf(format string, args ...interface{}) {
+	if l.level <= LogLevelDebug {
+		l.logger.Debugf(format, args...)
+	}
+}
+
+func (l *leveledLogger) Infof(format string, args ...interface{}) {
+	if l.level <= LogLevelInfo {
+		l.logger.Infof(format, args...)
+	}
+}
+
+func (l *leveledLogger) Warnf(format string, args ...interface{}) {
+	if l.level <= LogLevelWarn {
+		l.logger.Warnf(format, args...)
+	}
+}
+
+func (l *leveledLogger) Errorf(format string, args ...interface{}) {
+	if l.level <= LogLevelError {
+		l.logger.Errorf(format, args...)
+	}
+}
@@ -0,0 +1,49 @@
+package playwright
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (r *recordingLogger) Debugf(format string, args ...interface{}) {
+	r.messages = append(r.messages, "debug: "+fmt.Sprintf(format, args...))
+}
+
+func (r *recordingLogger) Infof(format string, args ...interface{}) {
+	r.messages = append(r.messages, "info: "+fmt.Sprintf(format, args...))
+}
+
+func (r *recordingLogger) Warnf(format string, args ...interface{}) {
+	r.messages = append(r.messages, "warn: "+fmt.Sprintf(format, args...))
+}
+
+func (r *recordingLogger) Errorf(format string, args ...interface{}) {
+	r.messages = append(r.messages, "error: "+fmt.Sprintf(format, args...))
+}
+
+func logAllLevels(logger Logger) {
+	logger.Debugf("%d", 1)
+	logger.Infof("%d", 2)
+	logger.Warnf("%d", 3)
+	logger.Errorf("%d", 4)
+}
+
+func TestLeveledLogger(t *testing.T) {
+	recorder := &recordingLogger{}
+	logAllLevels(newLeveledLogger(recorder, LogLevelDebug))
+	require.Equal(t, []string{"debug: 1", "info: 2", "warn: 3", "error: 4"}, recorder.messages)
+
+	recorder = &recordingLogger{}
+	logAllLevels(newLeveledLogger(recorder, LogLevelWarn))
+	require.Equal(t, []string{"warn: 3", "error: 4"}, recorder.messages)
+
+	recorder = &recordingLogger{}
+	logAllLevels(newLeveledLogger(recorder, LogLevelOff))
+	require.Empty(t, recorder.messages)
+}
@@ -4,28 +4,35 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"log"
+	"math"
 	"os"
-	"time"
+	"sync"
+	"sync/atomic"
 )
 
 type pageImpl struct {
 	channelOwner
-	isClosed        bool
+	isClosed        atomic.Bool
+	closeReason     string
 	closedOrCrashed chan bool
 	video           *videoImpl
+	coverage        *coverageImpl
 	mouse           *mouseImpl
 	keyboard        *keyboardImpl
 	touchscreen     *touchscreenImpl
 	timeoutSettings *timeoutSettings
 	browserContext  *browserContextImpl
-	frames          []Frame
-	workers         []Worker
-	mainFrame       Frame
-	routes          []*routeHandlerEntry
-	viewportSize    *Size
-	ownedContext    BrowserContext
-	bindings        map[string]BindingCallFunction
+	// framesLock guards frames, which the dispatch goroutine updates while frames attach and detach
+	framesLock     sync.RWMutex
+	frames         []Frame
+	workers        []Worker
+	mainFrame      Frame
+	routes         []*routeHandlerEntry
+	viewportSize   *Size
+	ownedContext   BrowserContext
+	bindings       map[string]BindingCallFunction
+	cdpSessionLock sync.Mutex
+	cdp            CDPSession
 }
 
 func (p *pageImpl) Context() BrowserContext {
@@ -33,16 +40,31 @@ func (p *pageImpl) Context() BrowserContext {
 }
 
 func (p *pageImpl) Close(options ...PageCloseOptions) error {
+	runBeforeUnload := false
+	if len(options) == 1 {
+		if options[0].Reason != nil {
+			p.Lock()
+			p.closeReason = *options[0].Reason
+			p.Unlock()
+		}
+		runBeforeUnload = options[0].RunBeforeUnload != nil && *options[0].RunBeforeUnload
+	}
 	_, err := p.channel.Send("close", options)
 	if err == nil && p.ownedContext != nil {
 		err = p.ownedContext.Close()
 	}
-	if isSafeCloseError(err) || (len(options) == 1 && *(options[0].RunBeforeUnload)) {
+	if IsSafeCloseError(err) || runBeforeUnload {
 		return nil
 	}
 	return err
 }
 
+func (p *pageImpl) getCloseReason() string {
+	p.RLock()
+	defer p.RUnlock()
+	return p.closeReason
+}
+
 func (p *pageImpl) InnerText(selector string, options ...PageInnerTextOptions) (string, error) {
 	if len(options) == 1 {
 		return p.mainFrame.InnerText(selector, FrameInnerTextOptions(options[0]))
@@ -64,7 +86,11 @@ func (p *pageImpl) Opener() (Page, error) {
 		// not popup page or opener has been closed
 		return nil, nil
 	}
-	return channelOwner.(*pageImpl), nil
+	opener := channelOwner.(*pageImpl)
+	if opener.IsClosed() {
+		return nil, nil
+	}
+	return opener, nil
 }
 
 func (p *pageImpl) MainFrame() Frame {
@@ -81,12 +107,16 @@ func (p *pageImpl) Frame(options ...PageFrameOptions) Frame {
 		matcher = newURLMatcher(option.URL, p.browserContext.options.BaseURL)
 	}
 
-	for _, f := range p.frames {
-		if option.Name != nil && f.Name() == *option.Name {
-			return f
+	for _, f := range p.Frames() {
+		// a name takes precedence over the URL, which is then not checked
+		if option.Name != nil {
+			if f.Name() == *option.Name {
+				return f
+			}
+			continue
 		}
 
-		if option.URL != nil && matcher != nil && matcher.Matches(f.URL()) {
+		if matcher != nil && matcher.Matches(f.URL()) {
 			return f
 		}
 	}
@@ -95,7 +125,11 @@ func (p *pageImpl) Frame(options ...PageFrameOptions) Frame {
 }
 
 func (p *pageImpl) Frames() []Frame {
-	return p.frames
+	p.framesLock.RLock()
+	defer p.framesLock.RUnlock()
+	frames := make([]Frame, len(p.frames))
+	copy(frames, p.frames)
+	return frames
 }
 
 func (p *pageImpl) SetDefaultNavigationTimeout(timeout float64) {
@@ -209,7 +243,7 @@ func (p *pageImpl) Goto(url string, options ...PageGotoOptions) (Response, error
 func (p *pageImpl) Reload(options ...PageReloadOptions) (Response, error) {
 	channel, err := p.channel.Send("reload", options)
 	if err != nil {
-		return nil, err
+		return nil, wrapNavigationError(p.URL(), err)
 	}
 	channelOwner := fromNullableChannel(channel)
 	if channelOwner == nil {
@@ -228,7 +262,7 @@ func (p *pageImpl) WaitForLoadState(options ...PageWaitForLoadStateOptions) erro
 func (p *pageImpl) GoBack(options ...PageGoBackOptions) (Response, error) {
 	channel, err := p.channel.Send("goBack", options)
 	if err != nil {
-		return nil, err
+		return nil, wrapNavigationError(p.URL(), err)
 	}
 	channelOwner := fromNullableChannel(channel)
 	if channelOwner == nil {
@@ -241,7 +275,7 @@ func (p *pageImpl) GoBack(options ...PageGoBackOptions) (Response, error) {
 func (p *pageImpl) GoForward(options ...PageGoForwardOptions) (Response, error) {
 	channel, err := p.channel.Send("goForward", options)
 	if err != nil {
-		return nil, err
+		return nil, wrapNavigationError(p.URL(), err)
 	}
 	channelOwner := fromNullableChannel(channel)
 	if channelOwner == nil {
@@ -259,6 +293,39 @@ func (p *pageImpl) EmulateMedia(options ...PageEmulateMediaOptions) error {
 	return err
 }
 
+func (p *pageImpl) EmulateNetworkConditions(conditions NetworkConditions) error {
+	session, err := p.cdpSession()
+	if err != nil {
+		return fmt.Errorf("network conditions emulation is only supported in Chromium: %w", err)
+	}
+	if _, err := session.Send("Network.enable", nil); err != nil {
+		return err
+	}
+	_, err = session.Send("Network.emulateNetworkConditions", map[string]interface{}{
+		"offline":            conditions.Offline,
+		"latency":            conditions.Latency,
+		"downloadThroughput": conditions.DownloadThroughput,
+		"uploadThroughput":   conditions.UploadThroughput,
+	})
+	return err
+}
+
+// cdpSession returns a CDP session attached to the page, creating it on first use. Emulation state set through CDP
+// only lives as long as the session that set it, so the session is kept until the page closes.
+func (p *pageImpl) cdpSession() (CDPSession, error) {
+	p.cdpSessionLock.Lock()
+	defer p.cdpSessionLock.Unlock()
+	if p.cdp != nil {
+		return p.cdp, nil
+	}
+	session, err := p.browserContext.NewCDPSession(p)
+	if err != nil {
+		return nil, err
+	}
+	p.cdp = session
+	return session, nil
+}
+
 func (p *pageImpl) SetViewportSize(width, height int) error {
 	_, err := p.channel.Send("setViewportSize", map[string]interface{}{
 		"viewportSize": map[string]interface{}{
@@ -320,6 +387,16 @@ func (p *pageImpl) Screenshot(options ...PageScreenshotOptions) ([]byte, error)
 	var path *string
 	if len(options) > 0 {
 		path = options[0].Path
+		if waitForFonts := options[0].WaitForFonts; waitForFonts != nil {
+			option := options[0]
+			option.WaitForFonts = nil
+			options = []PageScreenshotOptions{option}
+			if *waitForFonts {
+				if err := p.waitForFonts(); err != nil {
+					return nil, err
+				}
+			}
+		}
 	}
 	data, err := p.channel.Send("screenshot", options)
 	if err != nil {
@@ -337,6 +414,51 @@ func (p *pageImpl) Screenshot(options ...PageScreenshotOptions) ([]byte, error)
 	return image, nil
 }
 
+// waitForFontsExpression resolves once the document has finished loading its fonts.
+const waitForFontsExpression = `async () => { await document.fonts.ready }`
+
+// waitForFonts waits for the fonts of every frame. Only the main frame is required to succeed, child frames may
+// detach while waiting.
+func (p *pageImpl) waitForFonts() error {
+	for _, frame := range p.Frames() {
+		if _, err := frame.Evaluate(waitForFontsExpression); err != nil && frame == p.MainFrame() {
+			return fmt.Errorf("could not wait for fonts: %w", err)
+		}
+	}
+	return nil
+}
+
+func (p *pageImpl) ScreenshotOfRegion(loc Locator, padding int, options ...PageScreenshotOptions) ([]byte, error) {
+	if padding < 0 {
+		return nil, fmt.Errorf("padding must not be negative, got %d", padding)
+	}
+	option := PageScreenshotOptions{}
+	if len(options) == 1 {
+		option = options[0]
+	}
+	if err := loc.ScrollIntoViewIfNeeded(LocatorScrollIntoViewIfNeededOptions{Timeout: option.Timeout}); err != nil {
+		return nil, err
+	}
+	box, err := loc.BoundingBox(LocatorBoundingBoxOptions{Timeout: option.Timeout})
+	if err != nil {
+		return nil, err
+	}
+	if box == nil {
+		return nil, errors.New("could not take screenshot of region: element is not visible")
+	}
+	// the bounding box is relative to the viewport, like the clip of a screenshot that is not full page
+	pad := float64(padding)
+	x, y := math.Max(box.X-pad, 0), math.Max(box.Y-pad, 0)
+	option.Clip = &Rect{
+		X:      x,
+		Y:      y,
+		Width:  box.X + box.Width + pad - x,
+		Height: box.Y + box.Height + pad - y,
+	}
+	option.FullPage = nil
+	return p.Screenshot(option)
+}
+
 func (p *pageImpl) PDF(options ...PagePdfOptions) ([]byte, error) {
 	var path *string
 	if len(options) > 0 {
@@ -438,49 +560,11 @@ func (p *pageImpl) ExpectEvent(event string, cb func() error, options ...PageExp
 }
 
 func (p *pageImpl) ExpectNavigation(cb func() error, options ...PageExpectNavigationOptions) (Response, error) {
-	option := PageExpectNavigationOptions{}
+	option := FrameExpectNavigationOptions{}
 	if len(options) == 1 {
-		option = options[0]
-	}
-	if option.WaitUntil == nil {
-		option.WaitUntil = WaitUntilStateLoad
-	}
-	if option.Timeout == nil {
-		option.Timeout = Float(p.timeoutSettings.NavigationTimeout())
-	}
-	deadline := time.Now().Add(time.Duration(*option.Timeout) * time.Millisecond)
-	var matcher *urlMatcher
-	if option.URL != nil {
-		matcher = newURLMatcher(option.URL, p.browserContext.options.BaseURL)
-	}
-	predicate := func(events ...interface{}) bool {
-		ev := events[0].(map[string]interface{})
-		if ev["error"] != nil {
-			print("error")
-		}
-		return matcher == nil || matcher.Matches(ev["url"].(string))
-	}
-	waiter := p.mainFrame.(*frameImpl).setNavigationWaiter(option.Timeout)
-
-	eventData, err := waiter.WaitForEvent(p.mainFrame.(*frameImpl), "navigated", predicate).RunAndWait(cb)
-	if err != nil || eventData == nil {
-		return nil, err
-	}
-
-	t := time.Until(deadline).Milliseconds()
-	if t > 0 {
-		err = p.mainFrame.(*frameImpl).waitForLoadStateImpl(string(*option.WaitUntil), Float(float64(t)), nil)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	event := eventData.(map[string]interface{})
-	if event["newDocument"] != nil && event["newDocument"].(map[string]interface{})["request"] != nil {
-		request := fromChannel(event["newDocument"].(map[string]interface{})["request"]).(*requestImpl)
-		return request.Response()
+		option = FrameExpectNavigationOptions(options[0])
 	}
-	return nil, nil
+	return p.mainFrame.ExpectNavigation(cb, option)
 }
 
 func (p *pageImpl) ExpectConsoleMessage(cb func() error, options ...PageExpectConsoleMessageOptions) (ConsoleMessage, error) {
@@ -597,7 +681,7 @@ func (p *pageImpl) Route(url interface{}, handler routeHandler, times ...int) er
 	return p.updateInterceptionPatterns()
 }
 
-func (p *pageImpl) GetAttribute(selector string, name string, options ...PageGetAttributeOptions) (string, error) {
+func (p *pageImpl) GetAttribute(selector string, name string, options ...PageGetAttributeOptions) (*string, error) {
 	if len(options) == 1 {
 		return p.mainFrame.GetAttribute(selector, name, FrameGetAttributeOptions(options[0]))
 	}
@@ -612,7 +696,7 @@ func (p *pageImpl) Hover(selector string, options ...PageHoverOptions) error {
 }
 
 func (p *pageImpl) IsClosed() bool {
-	return p.isClosed
+	return p.isClosed.Load()
 }
 
 func (p *pageImpl) AddInitScript(script Script) error {
@@ -711,9 +795,7 @@ func newPage(parent *channelOwner, objectType string, guid string, initializer m
 	)
 	bt.channel.On(
 		"pageError", func(ev map[string]interface{}) {
-			err := Error{}
-			remapMapToStruct(ev["error"].(map[string]interface{})["error"], &err)
-			bt.Emit("pageerror", parseError(err))
+			bt.Emit("pageerror", parsePageError(ev["error"].(map[string]interface{})))
 		},
 	)
 	bt.channel.On("popup", func(ev map[string]interface{}) {
@@ -758,7 +840,7 @@ func newPage(parent *channelOwner, objectType string, guid string, initializer m
 		"request":         "request",
 		"response":        "response",
 		"requestfinished": "requestFinished",
-		"responsefailed":  "responseFailed",
+		"requestfailed":   "requestFailed",
 		"filechooser":     "fileChooser",
 	})
 
@@ -775,21 +857,32 @@ func (p *pageImpl) onBinding(binding *bindingCallImpl) {
 
 func (p *pageImpl) onFrameAttached(frame *frameImpl) {
 	frame.page = p
+	p.framesLock.Lock()
 	p.frames = append(p.frames, frame)
+	p.framesLock.Unlock()
 	p.Emit("frameattached", frame)
 }
 
 func (p *pageImpl) onFrameDetached(frame *frameImpl) {
+	parent := frame.ParentFrame()
+	frame.Lock()
 	frame.detached = true
+	frame.parentFrame = nil
+	frame.Unlock()
+	p.framesLock.Lock()
 	frames := make([]Frame, 0)
 	for i := 0; i < len(p.frames); i++ {
 		if p.frames[i] != frame {
-			frames = append(frames, frame)
+			frames = append(frames, p.frames[i])
 		}
 	}
 	if len(frames) != len(p.frames) {
 		p.frames = frames
 	}
+	p.framesLock.Unlock()
+	if parent != nil {
+		parent.(*frameImpl).removeChildFrame(frame)
+	}
 	p.Emit("framedetached", frame)
 }
 
@@ -815,7 +908,7 @@ func (p *pageImpl) onRoute(route *routeImpl) {
 					return nil, err
 				}, true)
 				if err != nil {
-					log.Printf("could not update interception patterns: %v", err)
+					p.connection.logger.Errorf("could not update interception patterns: %v", err)
 				}
 			}
 			if <-handled {
@@ -827,8 +920,11 @@ func (p *pageImpl) onRoute(route *routeImpl) {
 }
 
 func (p *pageImpl) updateInterceptionPatterns() error {
-	patterns := prepareInterceptionPatterns(p.routes)
-	_, err := p.channel.Send("setNetworkInterceptionPatterns", map[string]interface{}{
+	patterns, err := prepareInterceptionPatterns(p.routes)
+	if err != nil {
+		return err
+	}
+	_, err = p.channel.Send("setNetworkInterceptionPatterns", map[string]interface{}{
 		"patterns": patterns,
 	})
 	return err
@@ -841,7 +937,7 @@ func (p *pageImpl) onWorker(worker *workerImpl) {
 }
 
 func (p *pageImpl) onClose() {
-	p.isClosed = true
+	p.isClosed.Store(true)
 	newPages := []Page{}
 	newBackgoundPages := []Page{}
 	if p.browserContext != nil {
@@ -884,8 +980,8 @@ func (p *pageImpl) Uncheck(selector string, options ...PageUncheckOptions) error
 	return p.mainFrame.Uncheck(selector)
 }
 
-func (p *pageImpl) WaitForTimeout(timeout float64) {
-	p.mainFrame.WaitForTimeout(timeout)
+func (p *pageImpl) WaitForTimeout(timeout float64) error {
+	return p.mainFrame.WaitForTimeout(timeout)
 }
 
 func (p *pageImpl) WaitForFunction(expression string, arg interface{}, options ...PageWaitForFunctionOptions) (JSHandle, error) {
@@ -916,6 +1012,16 @@ func (p *pageImpl) TextContent(selector string, options ...PageTextContentOption
 	return p.mainFrame.TextContent(selector)
 }
 
+func (p *pageImpl) Coverage() Coverage {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.coverage == nil {
+		p.coverage = newCoverage(p)
+	}
+	return p.coverage
+}
+
 func (p *pageImpl) Video() Video {
 	p.Lock()
 	defer p.Unlock()
@@ -1067,7 +1173,7 @@ func (p *pageImpl) GetByAltText(text interface{}, options ...PageGetByAltTextOpt
 			exact = true
 		}
 	}
-	return p.Locator(getByAltTextSelector(text, exact))
+	return p.mainFrame.(*frameImpl).getByLocator(getByAltTextSelector(text, exact))
 }
 
 func (p *pageImpl) GetByLabel(text interface{}, options ...PageGetByLabelOptions) Locator {
@@ -1077,7 +1183,7 @@ func (p *pageImpl) GetByLabel(text interface{}, options ...PageGetByLabelOptions
 			exact = true
 		}
 	}
-	return p.Locator(getByLabelSelector(text, exact))
+	return p.mainFrame.(*frameImpl).getByLocator(getByLabelSelector(text, exact))
 }
 
 func (p *pageImpl) GetByPlaceholder(text interface{}, options ...PageGetByPlaceholderOptions) Locator {
@@ -1087,28 +1193,27 @@ func (p *pageImpl) GetByPlaceholder(text interface{}, options ...PageGetByPlaceh
 			exact = true
 		}
 	}
-	return p.Locator(getByPlaceholderSelector(text, exact))
+	return p.mainFrame.(*frameImpl).getByLocator(getByPlaceholderSelector(text, exact))
 }
 
 func (p *pageImpl) GetByRole(role AriaRole, options ...PageGetByRoleOptions) Locator {
 	if len(options) == 1 {
-		return p.Locator(getByRoleSelector(role, LocatorGetByRoleOptions(options[0])))
+		return p.mainFrame.(*frameImpl).getByLocator(getByRoleSelector(role, LocatorGetByRoleOptions(options[0])))
 	}
-	return p.Locator(getByRoleSelector(role))
+	return p.mainFrame.(*frameImpl).getByLocator(getByRoleSelector(role))
 }
 
 func (p *pageImpl) GetByTestId(testId interface{}) Locator {
-	return p.Locator(getByTestIdSelector(getTestIdAttributeName(), testId))
+	return p.mainFrame.(*frameImpl).getByLocator(getByTestIdSelector(p.connection.testIdAttributeName(), testId))
 }
 
 func (p *pageImpl) GetByText(text interface{}, options ...PageGetByTextOptions) Locator {
-	exact := false
+	exact, ignoreDiacritics := false, false
 	if len(options) == 1 {
-		if *options[0].Exact {
-			exact = true
-		}
+		exact = options[0].Exact != nil && *options[0].Exact
+		ignoreDiacritics = options[0].IgnoreDiacritics != nil && *options[0].IgnoreDiacritics
 	}
-	return p.Locator(getByTextSelector(text, exact))
+	return p.mainFrame.(*frameImpl).getByLocator(getByTextSelector(text, exact, ignoreDiacritics))
 }
 
 func (p *pageImpl) GetByTitle(text interface{}, options ...PageGetByTitleOptions) Locator {
@@ -1118,7 +1223,7 @@ func (p *pageImpl) GetByTitle(text interface{}, options ...PageGetByTitleOptions
 			exact = true
 		}
 	}
-	return p.Locator(getByTitleSelector(text, exact))
+	return p.mainFrame.(*frameImpl).getByLocator(getByTitleSelector(text, exact))
 }
 
 func (p *pageImpl) FrameLocator(selector string) FrameLocator {
@@ -1169,7 +1274,7 @@ func (p *pageImpl) OnLoad(fn func(Page)) {
 	p.On("load", fn)
 }
 
-func (p *pageImpl) OnPageError(fn func(*Error)) {
+func (p *pageImpl) OnPageError(fn func(error)) {
 	p.On("pageerror", fn)
 }
 
@@ -1193,6 +1298,24 @@ func (p *pageImpl) OnResponse(fn func(Response)) {
 	p.On("response", fn)
 }
 
+func (p *pageImpl) OnRequestMatching(url interface{}, fn func(Request)) {
+	matcher := newURLMatcher(url, p.browserContext.options.BaseURL)
+	p.OnRequest(func(request Request) {
+		if matcher.Matches(request.URL()) {
+			fn(request)
+		}
+	})
+}
+
+func (p *pageImpl) OnResponseMatching(url interface{}, fn func(Response)) {
+	matcher := newURLMatcher(url, p.browserContext.options.BaseURL)
+	p.OnResponse(func(response Response) {
+		if matcher.Matches(response.URL()) {
+			fn(response)
+		}
+	})
+}
+
 func (p *pageImpl) OnWebSocket(fn func(WebSocket)) {
 	p.On("websocket", fn)
 }
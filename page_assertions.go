@@ -1,10 +1,5 @@
 package playwright
 
-import (
-	"net/url"
-	"path"
-)
-
 type pageAssertionsImpl struct {
 	assertionsBase
 	actualPage Page
@@ -26,7 +21,10 @@ func (pa *pageAssertionsImpl) ToHaveTitle(titleOrRegExp interface{}, options ...
 	if len(options) == 1 {
 		timeout = options[0].Timeout
 	}
-	expectedValues := toExpectedTextValues([]interface{}{titleOrRegExp}, false, true, nil)
+	expectedValues, err := toExpectedTextValues([]interface{}{titleOrRegExp}, false, true, nil)
+	if err != nil {
+		return err
+	}
 	return pa.expect(
 		"to.have.title",
 		frameExpectOptions{ExpectedText: expectedValues, Timeout: timeout},
@@ -43,12 +41,13 @@ func (pa *pageAssertionsImpl) ToHaveURL(urlOrRegExp interface{}, options ...Page
 
 	baseURL := pa.actualPage.Context().(*browserContextImpl).options.BaseURL
 	if urlPath, ok := urlOrRegExp.(string); ok && baseURL != nil {
-		u, _ := url.Parse(*baseURL)
-		u.Path = path.Join(u.Path, urlPath)
-		urlOrRegExp = u.String()
+		urlOrRegExp = resolveURL(*baseURL, urlPath)
 	}
 
-	expectedValues := toExpectedTextValues([]interface{}{urlOrRegExp}, false, false, nil)
+	expectedValues, err := toExpectedTextValues([]interface{}{urlOrRegExp}, false, false, nil)
+	if err != nil {
+		return err
+	}
 	return pa.expect(
 		"to.have.url",
 		frameExpectOptions{ExpectedText: expectedValues, Timeout: timeout},
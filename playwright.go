@@ -23,13 +23,24 @@ type Playwright struct {
 	WebKit    BrowserType
 	Request   APIRequest
 	Devices   map[string]*DeviceDescriptor
+	shared    bool
 }
 
-// Stop stops the Playwright instance
+// Stop stops the Playwright instance. For an instance returned by [RunShared] the driver is only stopped once every
+// user has called Stop, once for each call to RunShared.
 func (p *Playwright) Stop() error {
+	if p.shared && !releaseShared(p) {
+		return nil
+	}
 	return p.connection.Stop()
 }
 
+// Stats returns the traffic counters of the connection to the driver. A growing number of pending calls while
+// nothing is happening points at calls the driver never answered.
+func (p *Playwright) Stats() ConnectionStats {
+	return p.connection.Stats()
+}
+
 func (p *Playwright) setSelectors(selectors Selectors) {
 	selectorsOwner := fromChannel(p.initializer["selectors"]).(*selectorsOwnerImpl)
 	p.Selectors.(*selectorsImpl).removeChannel(selectorsOwner)
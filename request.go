@@ -21,6 +21,7 @@ type requestImpl struct {
 	redirectedFrom     Request
 	redirectedTo       Request
 	failureText        string
+	response           *responseImpl
 	fallbackOverrides  *serializedFallbackOverrides
 }
 
@@ -32,7 +33,8 @@ func (r *requestImpl) URL() string {
 }
 
 func (r *requestImpl) ResourceType() string {
-	return r.initializer["resourceType"].(string)
+	resourceType, _ := r.initializer["resourceType"].(string)
+	return resourceType
 }
 
 func (r *requestImpl) Method() string {
@@ -89,16 +91,17 @@ func (r *requestImpl) Response() (Response, error) {
 }
 
 func (r *requestImpl) Frame() Frame {
-	channel, ok := r.initializer["frame"]
-	if !ok {
+	frame := fromNullableChannel(r.initializer["frame"])
+	if frame == nil {
 		// Service Worker requests do not have an associated frame.
 		return nil
 	}
-	return fromChannel(channel).(*frameImpl)
+	return frame.(*frameImpl)
 }
 
 func (r *requestImpl) IsNavigationRequest() bool {
-	return r.initializer["isNavigationRequest"].(bool)
+	isNavigationRequest, _ := r.initializer["isNavigationRequest"].(bool)
+	return isNavigationRequest
 }
 
 func (r *requestImpl) RedirectedFrom() Request {
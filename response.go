@@ -3,7 +3,7 @@ package playwright
 import (
 	"encoding/base64"
 	"encoding/json"
-	"errors"
+	"sync"
 )
 
 type responseImpl struct {
@@ -11,7 +11,9 @@ type responseImpl struct {
 	request            *requestImpl
 	provisionalHeaders *rawHeaders
 	rawHeaders         *rawHeaders
-	finished           chan error
+	finished           chan struct{}
+	finishedOnce       sync.Once
+	finishedErr        error
 }
 
 func (r *responseImpl) FromServiceWorker() bool {
@@ -44,14 +46,31 @@ func (r *responseImpl) Finished() error {
 		page = frame.Page()
 	}
 	if page != nil {
+		select {
+		case <-r.finished:
+			return r.finishedErr
+		default:
+		}
 		select {
 		case <-page.(*pageImpl).closedOrCrashed:
-			return errors.New("Target closed")
-		case err := <-r.finished:
-			return err
+			return &TargetClosedError{
+				Reason: page.(*pageImpl).getCloseReason(),
+				err:    &Error{Name: "TargetClosedError", Message: errMsgBrowserOrContextClosed},
+			}
+		case <-r.finished:
+			return r.finishedErr
 		}
 	}
-	return <-r.finished
+	<-r.finished
+	return r.finishedErr
+}
+
+// setFinished unblocks [Response.Finished], reporting err if the request failed after its response was received.
+func (r *responseImpl) setFinished(err error) {
+	r.finishedOnce.Do(func() {
+		r.finishedErr = err
+		close(r.finished)
+	})
 }
 
 func (r *responseImpl) Body() ([]byte, error) {
@@ -150,6 +169,7 @@ func newResponse(parent *channelOwner, objectType string, guid string, initializ
 	resp.createChannelOwner(resp, parent, objectType, guid, initializer)
 	timing := resp.initializer["timing"].(map[string]interface{})
 	resp.request = fromChannel(resp.initializer["request"]).(*requestImpl)
+	resp.request.response = resp
 	resp.request.timing = &RequestTiming{
 		StartTime:             timing["startTime"].(float64),
 		DomainLookupStart:     timing["domainLookupStart"].(float64),
@@ -161,6 +181,6 @@ func newResponse(parent *channelOwner, objectType string, guid string, initializ
 		ResponseStart:         timing["responseStart"].(float64),
 	}
 	resp.provisionalHeaders = newRawHeaders(resp.initializer["headers"])
-	resp.finished = make(chan error, 1)
+	resp.finished = make(chan struct{})
 	return resp
 }
@@ -10,6 +10,26 @@ import (
 	"strings"
 )
 
+// BlockResourceTypes returns a route handler that aborts requests of the given resource types, e.g. `image`, `font`
+// or `media`, and falls back to the next matching handler for everything else. Pass it to [Page.Route] or
+// [BrowserContext.Route] to keep tests from loading assets they don't need:
+//
+//	page.Route("**/*", playwright.BlockResourceTypes("image", "font", "media"))
+func BlockResourceTypes(resourceTypes ...string) func(Route) {
+	blocked := make(map[string]bool, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		blocked[resourceType] = true
+	}
+	return func(route Route) {
+		// both only fail when the page or context is already closed, so there is nothing left to do
+		if blocked[route.Request().ResourceType()] {
+			_ = route.Abort("blockedbyclient")
+		} else {
+			_ = route.Fallback()
+		}
+	}
+}
+
 type routeImpl struct {
 	channelOwner
 	handling *chan bool
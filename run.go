@@ -2,30 +2,44 @@ package playwright
 
 import (
 	"archive/zip"
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
 	playwrightCliVersion = "1.37.1"
 	baseURL              = "https://playwright.azureedge.net/builds/driver"
+	browsersPathEnv      = "PLAYWRIGHT_BROWSERS_PATH"
 )
 
 type PlaywrightDriver struct {
 	DriverDirectory, DriverBinaryLocation, Version string
 	options                                        *RunOptions
+	logger                                         Logger
 }
 
 func NewDriver(options *RunOptions) (*PlaywrightDriver, error) {
+	version := playwrightCliVersion
+	if options.DriverVersion != "" {
+		if !isCompatibleDriverVersion(options.DriverVersion) {
+			return nil, fmt.Errorf("unsupported driver version %q: playwright-go requires a %s.x driver", options.DriverVersion, driverMinorVersion(playwrightCliVersion))
+		}
+		version = options.DriverVersion
+	}
 	baseDriverDirectory := options.DriverDirectory
 	if baseDriverDirectory == "" {
 		var err error
@@ -34,16 +48,32 @@ func NewDriver(options *RunOptions) (*PlaywrightDriver, error) {
 			return nil, fmt.Errorf("could not get default cache directory: %w", err)
 		}
 	}
-	driverDirectory := filepath.Join(baseDriverDirectory, "ms-playwright-go", playwrightCliVersion)
+	driverDirectory := filepath.Join(baseDriverDirectory, "ms-playwright-go", version)
 	driverBinaryLocation := filepath.Join(driverDirectory, getDriverName())
 	return &PlaywrightDriver{
 		options:              options,
 		DriverBinaryLocation: driverBinaryLocation,
 		DriverDirectory:      driverDirectory,
-		Version:              playwrightCliVersion,
+		Version:              version,
+		logger:               newLeveledLogger(options.Logger, options.LogLevel),
 	}, nil
 }
 
+// isCompatibleDriverVersion reports whether version speaks the same protocol as the bundled driver. Patch
+// releases of a driver never change the protocol, so only the major and minor version have to match.
+func isCompatibleDriverVersion(version string) bool {
+	return driverMinorVersion(version) == driverMinorVersion(playwrightCliVersion) &&
+		strings.Count(version, ".") >= 2
+}
+
+func driverMinorVersion(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
 func getDefaultCacheDirectory() (string, error) {
 	userHomeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -77,6 +107,10 @@ func (d *PlaywrightDriver) isUpToDateDriver() (bool, error) {
 	if bytes.Contains(output, []byte(d.Version)) {
 		return true, nil
 	}
+	// A pinned driver is never replaced, as it may have been staged for an offline environment.
+	if d.options.DriverVersion != "" {
+		return false, fmt.Errorf("driver at %s reports %q, but version %s was requested", d.DriverBinaryLocation, strings.TrimSpace(string(output)), d.Version)
+	}
 	return false, nil
 }
 
@@ -88,13 +122,13 @@ func (d *PlaywrightDriver) install() error {
 		return nil
 	}
 	if d.options.Verbose {
-		log.Println("Downloading browsers...")
+		d.logger.Infof("Downloading browsers...")
 	}
 	if err := d.installBrowsers(d.DriverBinaryLocation); err != nil {
 		return fmt.Errorf("could not install browsers: %w", err)
 	}
 	if d.options.Verbose {
-		log.Println("Downloaded browsers successfully")
+		d.logger.Infof("Downloaded browsers successfully")
 	}
 	return nil
 }
@@ -107,7 +141,7 @@ func (d *PlaywrightDriver) DownloadDriver() error {
 		return nil
 	}
 
-	log.Printf("Downloading driver to %s", d.DriverDirectory)
+	d.logger.Infof("Downloading driver to %s", d.DriverDirectory)
 	driverURL := d.getDriverURL()
 	resp, err := http.Get(driverURL)
 	if err != nil {
@@ -160,13 +194,29 @@ func (d *PlaywrightDriver) DownloadDriver() error {
 		}
 	}
 
-	log.Println("Downloaded driver successfully")
+	d.logger.Infof("Downloaded driver successfully")
 	return nil
 }
 
 func (d *PlaywrightDriver) run() (*connection, error) {
+	if d.options.DriverVersion != "" {
+		upToDate, err := d.isUpToDateDriver()
+		if err != nil {
+			return nil, err
+		}
+		if !upToDate {
+			return nil, fmt.Errorf("driver %s is not installed in %s", d.Version, d.DriverDirectory)
+		}
+	}
 	cmd := exec.Command(d.DriverBinaryLocation, "run-driver")
-	cmd.Stderr = os.Stderr
+	cmd.Env = d.env()
+	cmd.Dir = d.options.WorkingDirectory
+	// stderr is read through an explicit pipe so its tail is known once the driver exits.
+	stderrReader, stderrWriter, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("could not get stderr pipe: %w", err)
+	}
+	cmd.Stderr = stderrWriter
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
 		return nil, fmt.Errorf("could not get stdin pipe: %w", err)
@@ -176,14 +226,23 @@ func (d *PlaywrightDriver) run() (*connection, error) {
 		return nil, fmt.Errorf("could not get stdout pipe: %w", err)
 	}
 	if err := cmd.Start(); err != nil {
+		stderrReader.Close()
+		stderrWriter.Close()
 		return nil, fmt.Errorf("could not start driver: %w", err)
 	}
-	transport := newPipeTransport(stdin, stdout)
+	stderrWriter.Close()
+	stderr := &stderrTail{}
+	stderrDone := make(chan struct{})
 	go func() {
-		if err := transport.Start(); err != nil {
-			log.Fatal(err)
+		defer close(stderrDone)
+		defer stderrReader.Close()
+		var output io.Writer = os.Stderr
+		if d.options.Stderr != nil {
+			output = d.options.Stderr
 		}
+		_, _ = io.Copy(io.MultiWriter(output, stderr), stderrReader)
 	}()
+	transport := newPipeTransport(stdin, stdout, d.logger)
 	connection := newConnection(func() error {
 		if err := stdin.Close(); err != nil {
 			return fmt.Errorf("could not close stdin: %v", err)
@@ -199,36 +258,243 @@ func (d *PlaywrightDriver) run() (*connection, error) {
 		}
 		return nil
 	})
+	// settings read while dispatching messages are set before the transport starts delivering them
+	connection.logger = d.logger
+	connection.startTimeout = d.options.StartTimeout
+	connection.asyncEvents = d.options.AsyncEventHandlers
+	connection.eventReplayBufferSize = d.options.EventReplayBufferSize
 	connection.onmessage = transport.Send
 	transport.onmessage = connection.Dispatch
+	transport.stats = &connection.stats
+	go func() {
+		err := transport.Start()
+		if err == nil {
+			err = errors.New("driver exited unexpectedly")
+		}
+		// Processes spawned by the driver may keep stderr open, so do not wait for it forever.
+		select {
+		case <-stderrDone:
+		case <-time.After(time.Second):
+		}
+		if output := stderr.String(); output != "" {
+			err = fmt.Errorf("%w\ndriver stderr:\n%s", err, output)
+		}
+		connection.cleanupWithError(err)
+	}()
 	return connection, nil
 }
 
+// stderrTail keeps the last bytes written to the driver's stderr, so they can be reported when the driver dies.
+type stderrTail struct {
+	sync.Mutex
+	buf []byte
+}
+
+const stderrTailSize = 4096
+
+func (s *stderrTail) Write(p []byte) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+	s.buf = append(s.buf, p...)
+	if len(s.buf) > stderrTailSize {
+		s.buf = s.buf[len(s.buf)-stderrTailSize:]
+	}
+	return len(p), nil
+}
+
+func (s *stderrTail) String() string {
+	s.Lock()
+	defer s.Unlock()
+	return strings.TrimSpace(string(s.buf))
+}
+
 func (d *PlaywrightDriver) installBrowsers(driverPath string) error {
 	additionalArgs := []string{"install"}
 	if d.options.Browsers != nil {
 		additionalArgs = append(additionalArgs, d.options.Browsers...)
 	}
 	cmd := exec.Command(driverPath, additionalArgs...)
-	cmd.Stdout = os.Stdout
+	cmd.Env = d.env()
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if d.options.OnInstallProgress == nil {
+		cmd.Stdout = os.Stdout
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("could not install browsers: %w", err)
+		}
+		return nil
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("could not get stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("could not install browsers: %w", err)
+	}
+	var output io.Reader = stdout
+	if d.options.Verbose {
+		output = io.TeeReader(stdout, os.Stdout)
+	}
+	progress := &installProgress{callback: d.options.OnInstallProgress}
+	scanner := bufio.NewScanner(output)
+	scanner.Split(scanInstallOutputLines)
+	for scanner.Scan() {
+		progress.parseLine(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		d.logger.Warnf("could not read install progress: %v", err)
+	}
+	// keep reading after a scan error, otherwise the installer blocks on a full pipe and Wait never returns
+	_, _ = io.Copy(io.Discard, output)
+	if err := cmd.Wait(); err != nil {
 		return fmt.Errorf("could not install browsers: %w", err)
 	}
 	return nil
 }
 
+var (
+	installDownloadingPattern = regexp.MustCompile(`^Downloading (.+?) (?:[\d.]+ \()?playwright build`)
+	installProgressPattern    = regexp.MustCompile(`(\d+)% of ([\d.]+) ([KMG]i?B|[KMG]b)`)
+	installDownloadedPattern  = regexp.MustCompile(` downloaded to `)
+)
+
+// installProgress turns the progress lines printed by "playwright install" into callback invocations.
+type installProgress struct {
+	callback func(downloaded, total int64, browser string)
+	browser  string
+	total    int64
+}
+
+func (p *installProgress) parseLine(line string) {
+	line = strings.TrimSpace(line)
+	if match := installDownloadingPattern.FindStringSubmatch(line); match != nil {
+		p.browser = match[1]
+		p.total = 0
+		return
+	}
+	if match := installProgressPattern.FindStringSubmatch(line); match != nil && p.browser != "" {
+		percent, _ := strconv.ParseInt(match[1], 10, 64)
+		size, _ := strconv.ParseFloat(match[2], 64)
+		p.total = int64(size * float64(installSizeUnit(match[3])))
+		p.callback(p.total*percent/100, p.total, p.browser)
+		return
+	}
+	if installDownloadedPattern.MatchString(line) && p.browser != "" {
+		p.callback(p.total, p.total, p.browser)
+		p.browser = ""
+	}
+}
+
+func installSizeUnit(unit string) int64 {
+	switch strings.ToUpper(unit[:1]) {
+	case "K":
+		return 1 << 10
+	case "M":
+		return 1 << 20
+	case "G":
+		return 1 << 30
+	}
+	return 1
+}
+
+// scanInstallOutputLines splits on both \n and \r, as the progress bar is redrawn with carriage returns on terminals.
+func scanInstallOutputLines(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// env returns the environment for driver processes: the current environment, overridden by Env and then by
+// BrowsersPath, which takes precedence over an inherited or configured PLAYWRIGHT_BROWSERS_PATH.
+func (d *PlaywrightDriver) env() []string {
+	overrides := make(map[string]string, len(d.options.Env)+1)
+	for key, value := range d.options.Env {
+		overrides[key] = value
+	}
+	if d.options.BrowsersPath != "" {
+		overrides[browsersPathEnv] = d.options.BrowsersPath
+	}
+	env := os.Environ()
+	if len(overrides) == 0 {
+		return env
+	}
+	filtered := make([]string, 0, len(env)+len(overrides))
+	for _, kv := range env {
+		key, _, _ := strings.Cut(kv, "=")
+		if _, ok := overrides[key]; !ok {
+			filtered = append(filtered, kv)
+		}
+	}
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		filtered = append(filtered, key+"="+overrides[key])
+	}
+	return filtered
+}
+
 // RunOptions are custom options to run the driver
 type RunOptions struct {
-	DriverDirectory     string
+	// DriverDirectory is the base directory the driver is downloaded to. Defaults to the user cache directory.
+	DriverDirectory string
+	// SkipInstallBrowsers only installs the driver, leaving browsers to be installed separately.
 	SkipInstallBrowsers bool
-	Browsers            []string
-	Verbose             bool
+	// Browsers limits the installation to the given browsers, e.g. []string{"chromium"}. Defaults to all browsers.
+	Browsers []string
+	// BrowsersPath is the directory browsers are installed to and launched from. When empty, the
+	// PLAYWRIGHT_BROWSERS_PATH environment variable is used, falling back to the driver's default location.
+	BrowsersPath string
+	// Verbose logs installation progress.
+	Verbose bool
+	// Logger receives the messages logged by playwright-go. Defaults to the standard library logger.
+	Logger Logger
+	// LogLevel is the minimum level of the messages passed to Logger. Defaults to LogLevelDebug, logging
+	// everything; use LogLevelOff to silence the library.
+	LogLevel LogLevel
+	// Env holds additional environment variables for the driver process, overriding inherited ones.
+	Env map[string]string
+	// Stderr receives the driver's stderr. Defaults to os.Stderr. The tail of it is also included in the
+	// error reported when the driver exits unexpectedly.
+	Stderr io.Writer
+	// AsyncEventHandlers calls event handlers, e.g. the ones passed to Page.OnConsole, on a goroutine dedicated to
+	// the object emitting the event instead of on the goroutine reading from the driver. A slow handler then only
+	// delays later events of the same object, not the events of other objects or the responses to pending calls.
+	// Handlers of one object are always called one at a time, in the order the events were emitted; events of
+	// different objects are not ordered relative to each other. The handlers that are called are the ones
+	// registered when the event is emitted.
+	//
+	// Without this option handlers are called synchronously when the event arrives, so every object observes
+	// events in arrival order, but a handler that blocks stalls the whole connection.
+	AsyncEventHandlers bool
+	// EventReplayBufferSize is the number of recent events of each name that objects keep, so handlers registered
+	// with [EventReplayer.OnWithReplay] also see the events emitted before they were registered, e.g. responses of a
+	// navigation that was started before the handler was attached. Zero disables the buffer. Keep it small: buffered
+	// events keep their payloads, such as Response objects, alive.
+	EventReplayBufferSize int
+	// StartTimeout limits how long Run waits for the driver to initialize. Zero means no limit.
+	StartTimeout time.Duration
+	// WorkingDirectory is the working directory of the driver process. Defaults to the current directory.
+	WorkingDirectory string
+	// DriverVersion pins the driver release to use, e.g. "1.37.0". Only patch releases of the driver this
+	// module was built against are accepted, because the protocol changes between minor releases. A pinned
+	// driver that is already installed is never replaced, and Run fails instead of using another version.
+	DriverVersion string
+	// OnInstallProgress is called while browsers are downloaded with the number of bytes downloaded so far,
+	// the total size and the name of the browser. When nil, the driver output is passed through as is.
+	OnInstallProgress func(downloaded, total int64, browser string)
 }
 
 // Install does download the driver and the browsers. If not called manually
 // before playwright.Run() it will get executed there and might take a few seconds
-// to download the Playwright suite.
+// to download the Playwright suite. Use [RunOptions] to only install some browsers
+// or to change where they are installed; pass the same options to [Run] so the
+// browsers are found again.
 func Install(options ...*RunOptions) error {
 	driver, err := NewDriver(transformRunOptions(options))
 	if err != nil {
@@ -250,12 +516,57 @@ func Run(options ...*RunOptions) (*Playwright, error) {
 	if err != nil {
 		return nil, err
 	}
-	playwright := connection.Start()
+	playwright, err := connection.Start()
+	if err != nil {
+		_ = connection.Stop()
+		return nil, err
+	}
 	return playwright, nil
 }
 
+var shared = struct {
+	sync.Mutex
+	playwright *Playwright
+	refs       int
+}{}
+
+// RunShared starts a Playwright driver on the first call and returns the same instance on subsequent calls, so that
+// a single driver process is shared across the process. [Playwright.Stop] must be called exactly once for every call
+// to RunShared; the driver is torn down once the last user stops it. All callers get the same instance, so calling
+// Stop twice drops a reference held by another caller. Options are only used when the driver is started.
+func RunShared(options ...*RunOptions) (*Playwright, error) {
+	shared.Lock()
+	defer shared.Unlock()
+	if shared.playwright == nil {
+		playwright, err := Run(options...)
+		if err != nil {
+			return nil, err
+		}
+		playwright.shared = true
+		shared.playwright = playwright
+	}
+	shared.refs++
+	return shared.playwright, nil
+}
+
+// releaseShared drops a reference to the shared instance and reports whether it should be stopped.
+func releaseShared(playwright *Playwright) bool {
+	shared.Lock()
+	defer shared.Unlock()
+	if shared.playwright != playwright {
+		return false
+	}
+	shared.refs--
+	if shared.refs > 0 {
+		return false
+	}
+	shared.playwright = nil
+	shared.refs = 0
+	return true
+}
+
 func transformRunOptions(options []*RunOptions) *RunOptions {
-	if len(options) == 1 {
+	if len(options) == 1 && options[0] != nil {
 		return options[0]
 	}
 	return &RunOptions{
@@ -0,0 +1,177 @@
+package playwright
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriverEnvBrowsersPathPrecedence(t *testing.T) {
+	t.Setenv(browsersPathEnv, "/from/env")
+
+	driver, err := NewDriver(&RunOptions{DriverDirectory: t.TempDir()})
+	require.NoError(t, err)
+	require.Contains(t, driver.env(), browsersPathEnv+"=/from/env")
+
+	driver, err = NewDriver(&RunOptions{DriverDirectory: t.TempDir(), BrowsersPath: "/from/options"})
+	require.NoError(t, err)
+	env := driver.env()
+	require.Contains(t, env, browsersPathEnv+"=/from/options")
+	require.NotContains(t, env, browsersPathEnv+"=/from/env")
+}
+
+func TestTransformRunOptions(t *testing.T) {
+	require.True(t, transformRunOptions(nil).Verbose)
+	require.True(t, transformRunOptions([]*RunOptions{nil}).Verbose)
+	options := &RunOptions{Browsers: []string{"chromium"}}
+	require.Same(t, options, transformRunOptions([]*RunOptions{options}))
+}
+
+func TestInstallProgressParseLine(t *testing.T) {
+	type call struct {
+		downloaded, total int64
+		browser           string
+	}
+	calls := []call{}
+	progress := &installProgress{callback: func(downloaded, total int64, browser string) {
+		calls = append(calls, call{downloaded, total, browser})
+	}}
+	for _, line := range []string{
+		"Downloading Chromium 116.0.5845.82 (playwright build v1076) from https://playwright.azureedge.net/builds/chromium/1076/chromium-linux.zip",
+		"|■■■■■■■■                                                                        |  10% of 100 Mb",
+		"|■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■| 100% of 100 Mb",
+		"Chromium 116.0.5845.82 (playwright build v1076) downloaded to /root/.cache/ms-playwright/chromium-1076",
+		"unrelated output",
+		"Downloading FFMPEG playwright build v1009 from https://playwright.azureedge.net/builds/ffmpeg/1009/ffmpeg-linux.zip",
+		"|■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■■| 100% of 2.6 Mb",
+	} {
+		progress.parseLine(line)
+	}
+	total := int64(100 << 20)
+	size := 2.6
+	ffmpeg := int64(size * (1 << 20))
+	require.Equal(t, []call{
+		{total / 10, total, "Chromium"},
+		{total, total, "Chromium"},
+		{total, total, "Chromium"},
+		{ffmpeg, ffmpeg, "FFMPEG"},
+	}, calls)
+}
+
+func TestNewDriverVersionPinning(t *testing.T) {
+	dir := t.TempDir()
+	driver, err := NewDriver(&RunOptions{DriverDirectory: dir})
+	require.NoError(t, err)
+	require.Equal(t, playwrightCliVersion, driver.Version)
+
+	driver, err = NewDriver(&RunOptions{DriverDirectory: dir, DriverVersion: driverMinorVersion(playwrightCliVersion) + ".0"})
+	require.NoError(t, err)
+	require.Equal(t, driverMinorVersion(playwrightCliVersion)+".0", driver.Version)
+	require.Equal(t, filepath.Join(dir, "ms-playwright-go", driver.Version), driver.DriverDirectory)
+
+	for _, version := range []string{"1.2.3", "0.0.0", "latest", driverMinorVersion(playwrightCliVersion)} {
+		_, err = NewDriver(&RunOptions{DriverDirectory: dir, DriverVersion: version})
+		require.ErrorContains(t, err, "unsupported driver version", version)
+	}
+}
+
+func TestPinnedDriverVersionMismatch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("driver stub is a shell script")
+	}
+	pinned := driverMinorVersion(playwrightCliVersion) + ".0"
+	driver, err := NewDriver(&RunOptions{DriverDirectory: t.TempDir(), DriverVersion: pinned})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(driver.DriverDirectory, 0777))
+	require.NoError(t, os.WriteFile(driver.DriverBinaryLocation, []byte("#!/bin/sh\necho Version 0.9.9\n"), 0755))
+
+	_, err = driver.isUpToDateDriver()
+	require.ErrorContains(t, err, "version "+pinned+" was requested")
+	_, err = driver.run()
+	require.ErrorContains(t, err, "version "+pinned+" was requested")
+
+	require.NoError(t, os.WriteFile(driver.DriverBinaryLocation, []byte("#!/bin/sh\necho Version "+pinned+"\n"), 0755))
+	upToDate, err := driver.isUpToDateDriver()
+	require.NoError(t, err)
+	require.True(t, upToDate)
+}
+
+func TestDriverRunReportsStderrWhenDriverExits(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("driver stub is a shell script")
+	}
+	workDir := t.TempDir()
+	var stderr bytes.Buffer
+	driver, err := NewDriver(&RunOptions{
+		DriverDirectory:  t.TempDir(),
+		Env:              map[string]string{"PLAYWRIGHT_GO_TEST": "from-options"},
+		Stderr:           &stderr,
+		WorkingDirectory: workDir,
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(driver.DriverDirectory, 0777))
+	require.NoError(t, os.WriteFile(driver.DriverBinaryLocation, []byte("#!/bin/sh\necho \"boom $PLAYWRIGHT_GO_TEST $(pwd)\" >&2\nexit 1\n"), 0755))
+
+	conn, err := driver.run()
+	require.NoError(t, err)
+	select {
+	case <-conn.abort:
+	case <-time.After(10 * time.Second):
+		t.Fatal("connection was not closed after the driver exited")
+	}
+	expected := "boom from-options " + workDir
+	require.ErrorContains(t, conn.closeError(), "driver exited unexpectedly")
+	require.ErrorContains(t, conn.closeError(), expected)
+	require.Contains(t, stderr.String(), expected)
+}
+
+func TestStderrTailKeepsLastBytes(t *testing.T) {
+	tail := &stderrTail{}
+	_, err := tail.Write(bytes.Repeat([]byte("a"), stderrTailSize))
+	require.NoError(t, err)
+	_, err = tail.Write([]byte("end"))
+	require.NoError(t, err)
+	require.Len(t, tail.String(), stderrTailSize)
+	require.True(t, strings.HasSuffix(tail.String(), "aend"))
+}
+
+func TestRunReturnsErrorWhenDriverFailsToStart(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("driver stub is a shell script")
+	}
+	options := &RunOptions{DriverDirectory: t.TempDir(), Stderr: io.Discard}
+	driver, err := NewDriver(options)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(driver.DriverDirectory, 0777))
+	require.NoError(t, os.WriteFile(driver.DriverBinaryLocation, []byte("#!/bin/sh\necho 'cannot start' >&2\nexit 1\n"), 0755))
+
+	pw, err := Run(options)
+	require.Nil(t, pw)
+	require.ErrorContains(t, err, "could not initialize playwright")
+	require.ErrorContains(t, err, "cannot start")
+}
+
+func TestRunStartTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("driver stub is a shell script")
+	}
+	options := &RunOptions{DriverDirectory: t.TempDir(), StartTimeout: 200 * time.Millisecond}
+	driver, err := NewDriver(options)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(driver.DriverDirectory, 0777))
+	// The stub never answers, but keeps the pipes open.
+	require.NoError(t, os.WriteFile(driver.DriverBinaryLocation, []byte("#!/bin/sh\nexec sleep 30\n"), 0755))
+
+	start := time.Now()
+	pw, err := Run(options)
+	require.Nil(t, pw)
+	require.ErrorContains(t, err, "timed out after 200ms")
+	require.Less(t, time.Since(start), 10*time.Second)
+}
@@ -4,13 +4,31 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"strings"
 
 	"github.com/playwright-community/playwright-go"
 )
 
 func main() {
-	if err := playwright.Install(); err != nil {
+	browsers := flag.String("browsers", "", "comma separated list of browsers to install, e.g. chromium,firefox (default all)")
+	driverDirectory := flag.String("driver-dir", "", "directory to install the driver to")
+	browsersPath := flag.String("browsers-path", "", "directory to install the browsers to (overrides PLAYWRIGHT_BROWSERS_PATH)")
+	skipBrowsers := flag.Bool("skip-browsers", false, "only install the driver")
+	verbose := flag.Bool("verbose", true, "log installation progress")
+	flag.Parse()
+
+	options := &playwright.RunOptions{
+		DriverDirectory:     *driverDirectory,
+		BrowsersPath:        *browsersPath,
+		SkipInstallBrowsers: *skipBrowsers,
+		Verbose:             *verbose,
+	}
+	if *browsers != "" {
+		options.Browsers = strings.Split(*browsers, ",")
+	}
+	if err := playwright.Install(options); err != nil {
 		log.Fatalf("could not install playwright: %v", err)
 	}
 }
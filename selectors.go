@@ -17,8 +17,10 @@ func newSelectorsOwner(parent *channelOwner, objectType string, guid string, ini
 }
 
 type selectorsImpl struct {
-	channels      sync.Map
-	registrations []map[string]interface{}
+	sync.RWMutex
+	channels            sync.Map
+	registrations       []map[string]interface{}
+	testIdAttributeName string
 }
 
 func (s *selectorsImpl) Register(name string, script Script, options ...SelectorsRegisterOptions) error {
@@ -55,7 +57,9 @@ func (s *selectorsImpl) Register(name string, script Script, options ...Selector
 }
 
 func (s *selectorsImpl) SetTestIdAttribute(name string) {
-	setTestIdAttributeName(name)
+	s.Lock()
+	s.testIdAttributeName = name
+	s.Unlock()
 	s.channels.Range(func(key, value any) bool {
 		value.(*selectorsOwnerImpl).channel.SendNoReply("setTestIdAttributeName", map[string]interface{}{
 			"testIdAttributeName": name,
@@ -64,14 +68,21 @@ func (s *selectorsImpl) SetTestIdAttribute(name string) {
 	})
 }
 
+func (s *selectorsImpl) getTestIdAttributeName() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.testIdAttributeName
+}
+
 func (s *selectorsImpl) addChannel(channel *selectorsOwnerImpl) {
 	s.channels.Store(channel.guid, channel)
+	channel.connection.selectors = s
 	for _, params := range s.registrations {
 		channel.channel.SendNoReply("register", params)
-		channel.channel.SendNoReply("setTestIdAttributeName", map[string]interface{}{
-			"testIdAttributeName": getTestIdAttributeName(),
-		})
 	}
+	channel.channel.SendNoReply("setTestIdAttributeName", map[string]interface{}{
+		"testIdAttributeName": s.getTestIdAttributeName(),
+	})
 }
 
 func (s *selectorsImpl) removeChannel(channel *selectorsOwnerImpl) {
@@ -80,7 +91,8 @@ func (s *selectorsImpl) removeChannel(channel *selectorsOwnerImpl) {
 
 func newSelectorsImpl() *selectorsImpl {
 	return &selectorsImpl{
-		channels:      sync.Map{},
-		registrations: make([]map[string]interface{}, 0),
+		channels:            sync.Map{},
+		registrations:       make([]map[string]interface{}, 0),
+		testIdAttributeName: defaultTestIdAttributeName,
 	}
 }
@@ -1,17 +1,58 @@
 package playwright
 
 import (
-	"bufio"
 	"encoding/base64"
+	"io"
 	"os"
 	"path/filepath"
+	"sync"
 )
 
+// streamChunkSize is the number of bytes requested from the driver per read.
+const streamChunkSize = 1024 * 1024
+
 type streamImpl struct {
 	channelOwner
+	readMu  sync.Mutex
+	pending []byte
+	eof     bool
+}
+
+// Read implements io.Reader, fetching one chunk at a time from the driver so the
+// whole stream is never held in memory.
+func (s *streamImpl) Read(p []byte) (int, error) {
+	s.readMu.Lock()
+	defer s.readMu.Unlock()
+	for len(s.pending) == 0 {
+		if s.eof {
+			return 0, io.EOF
+		}
+		binary, err := s.channel.Send("read", map[string]interface{}{"size": streamChunkSize})
+		if err != nil {
+			return 0, err
+		}
+		chunk, err := base64.StdEncoding.DecodeString(binary.(string))
+		if err != nil {
+			return 0, err
+		}
+		if len(chunk) == 0 {
+			s.eof = true
+		}
+		s.pending = chunk
+	}
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+// Close releases the stream on the driver side.
+func (s *streamImpl) Close() error {
+	_, err := s.channel.Send("close")
+	return err
 }
 
 func (s *streamImpl) SaveAs(path string) error {
+	defer s.Close()
 	err := os.MkdirAll(filepath.Dir(path), 0777)
 	if err != nil {
 		return err
@@ -20,45 +61,16 @@ func (s *streamImpl) SaveAs(path string) error {
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-	writer := bufio.NewWriter(file)
-	for {
-		binary, err := s.channel.Send("read", map[string]interface{}{"size": 1024 * 1024})
-		if err != nil {
-			return err
-		}
-		bytes, err := base64.StdEncoding.DecodeString(binary.(string))
-		if err != nil {
-			return err
-		}
-		if len(bytes) == 0 {
-			break
-		}
-		_, err = writer.Write(bytes)
-		if err != nil {
-			return err
-		}
+	if _, err := io.Copy(file, s); err != nil {
+		file.Close()
+		return err
 	}
-	return nil
+	return file.Close()
 }
 
 func (s *streamImpl) ReadAll() ([]byte, error) {
-	var data []byte
-	for {
-		binary, err := s.channel.Send("read", map[string]interface{}{"size": 1024 * 1024})
-		if err != nil {
-			return nil, err
-		}
-		bytes, err := base64.StdEncoding.DecodeString(binary.(string))
-		if err != nil {
-			return nil, err
-		}
-		if len(bytes) == 0 {
-			break
-		}
-		data = append(data, bytes...)
-	}
-	return data, nil
+	defer s.Close()
+	return io.ReadAll(s)
 }
 
 func newStream(parent *channelOwner, objectType string, guid string, initializer map[string]interface{}) *streamImpl {
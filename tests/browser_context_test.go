@@ -129,6 +129,53 @@ func TestBrowserContextNewCDPSession(t *testing.T) {
 	}
 }
 
+func TestBrowserContextNewCDPSessionForFrame(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	cdpSession, err := context.NewCDPSession(page.MainFrame())
+	if !isChromium {
+		require.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+	result, err := cdpSession.Send("Runtime.evaluate", map[string]interface{}{
+		"expression":    "1 + 2",
+		"returnByValue": true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, result["result"].(map[string]interface{})["value"])
+	require.NoError(t, cdpSession.Detach())
+}
+
+func TestBrowserContextNewCDPSessionShouldRejectInvalidTarget(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := context.NewCDPSession(context)
+	require.ErrorContains(t, err, "not page or frame")
+}
+
+func TestBrowserContextNewCDPSessionShouldDetachWhenPageCloses(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	newPage, err := context.NewPage()
+	require.NoError(t, err)
+	cdpSession, err := context.NewCDPSession(newPage)
+	if !isChromium {
+		require.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+	_, err = cdpSession.Send("Emulation.setCPUThrottlingRate", map[string]interface{}{
+		"rate": 2,
+	})
+	require.NoError(t, err)
+	require.NoError(t, newPage.Close())
+	_, err = cdpSession.Send("Emulation.setCPUThrottlingRate", map[string]interface{}{
+		"rate": 1,
+	})
+	require.Error(t, err)
+}
+
 func TestBrowserContextSetGeolocation(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -332,6 +379,56 @@ func TestPageEventShouldHaveURL(t *testing.T) {
 	require.Equal(t, server.EMPTY_PAGE, newPage.URL())
 }
 
+func TestBrowserContextOnPageShouldFireForPopups(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	pages := make(chan playwright.Page, 2)
+	context.OnPage(func(p playwright.Page) {
+		pages <- p
+	})
+	newPage, err := context.NewPage()
+	require.NoError(t, err)
+	require.Equal(t, newPage, <-pages)
+
+	_, err = page.Evaluate("url => window.open(url)", server.EMPTY_PAGE)
+	require.NoError(t, err)
+	popup := <-pages
+	require.Equal(t, context, popup.Context())
+	opener, err := popup.Opener()
+	require.NoError(t, err)
+	require.Equal(t, page, opener)
+	require.Contains(t, context.Pages(), popup)
+}
+
+func TestBrowserContextPagesShouldTrackOpenAndClosedPages(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	page2, err := context.NewPage()
+	require.NoError(t, err)
+	page3, err := context.NewPage()
+	require.NoError(t, err)
+	pages := context.Pages()
+	require.Equal(t, []playwright.Page{page, page2, page3}, pages)
+
+	require.NoError(t, page2.Close())
+	require.Equal(t, []playwright.Page{page, page3}, context.Pages())
+	// slices returned earlier are snapshots and are not modified afterwards
+	require.Equal(t, []playwright.Page{page, page2, page3}, pages)
+}
+
+func TestBrowserContextOnCloseShouldFire(t *testing.T) {
+	context2, err := browser.NewContext()
+	require.NoError(t, err)
+	closed := make(chan playwright.BrowserContext, 1)
+	context2.OnClose(func(c playwright.BrowserContext) {
+		closed <- c
+	})
+	require.NoError(t, context2.Close())
+	require.Equal(t, context2, <-closed)
+}
+
 func TestConsoleEventShouldWork(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -495,3 +592,100 @@ func TestBrowserContextCloseShouldBeCallableTwice(t *testing.T) {
 	require.NoError(t, context.Close())
 	require.NoError(t, context.Close())
 }
+
+func TestBrowserContextBaseURLShouldResolveNavigation(t *testing.T) {
+	testCases := []struct {
+		baseURL  string
+		url      string
+		expected string
+	}{
+		{server.PREFIX, "/empty.html", server.EMPTY_PAGE},
+		{server.PREFIX + "/", "/empty.html", server.EMPTY_PAGE},
+		{server.PREFIX + "/", "empty.html", server.EMPTY_PAGE},
+		{server.PREFIX + "/frames/", "frame.html", server.PREFIX + "/frames/frame.html"},
+		{server.PREFIX + "/frames/", "/empty.html", server.EMPTY_PAGE},
+		{server.PREFIX + "/frames", "empty.html", server.EMPTY_PAGE},
+		{"http://example.invalid/app/", server.EMPTY_PAGE, server.EMPTY_PAGE},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.baseURL+"+"+tc.url, func(t *testing.T) {
+			BeforeEach(t, playwright.BrowserNewContextOptions{
+				BaseURL: playwright.String(tc.baseURL),
+			})
+			defer AfterEach(t)
+			response, err := page.Goto(tc.url)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, response.URL())
+			require.Equal(t, tc.expected, page.URL())
+		})
+	}
+}
+
+func TestBrowserContextBaseURLShouldApplyToRouteMatching(t *testing.T) {
+	BeforeEach(t, playwright.BrowserNewContextOptions{
+		BaseURL: playwright.String(server.PREFIX + "/frames/"),
+	})
+	defer AfterEach(t)
+	routed := make(chan string, 2)
+	require.NoError(t, page.Route("frame.html", func(route playwright.Route) {
+		routed <- route.Request().URL()
+		require.NoError(t, route.Continue())
+	}))
+	require.NoError(t, context.Route("/empty.html", func(route playwright.Route) {
+		routed <- route.Request().URL()
+		require.NoError(t, route.Continue())
+	}))
+	_, err := page.Goto("frame.html")
+	require.NoError(t, err)
+	require.Equal(t, server.PREFIX+"/frames/frame.html", <-routed)
+	_, err = page.Goto("/empty.html")
+	require.NoError(t, err)
+	require.Equal(t, server.EMPTY_PAGE, <-routed)
+	require.NoError(t, expect.Page(page).ToHaveURL("/empty.html"))
+	require.NoError(t, page.Unroute("frame.html"))
+}
+
+func TestBrowserContextBaseURLShouldApplyToAPIRequestContext(t *testing.T) {
+	BeforeEach(t, playwright.BrowserNewContextOptions{
+		BaseURL: playwright.String(server.PREFIX + "/"),
+	})
+	defer AfterEach(t)
+	response, err := context.Request().Get("empty.html")
+	require.NoError(t, err)
+	require.Equal(t, 200, response.Status())
+	require.Equal(t, server.EMPTY_PAGE, response.URL())
+}
+
+func TestBrowserContextShouldUsePerContextProxy(t *testing.T) {
+	proxy2 := newTestServer()
+	defer proxy2.testServer.Close()
+	server.SetRoute("/target.html", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><title>Served by the proxy</title></html>"))
+	})
+	proxy2.SetRoute("/target.html", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><title>Served by the second proxy</title></html>"))
+	})
+
+	for proxy, title := range map[string]string{
+		server.PREFIX: "Served by the proxy",
+		proxy2.PREFIX: "Served by the second proxy",
+	} {
+		context, err := browser.NewContext(playwright.BrowserNewContextOptions{
+			Proxy: &playwright.Proxy{Server: proxy},
+		})
+		require.NoError(t, err)
+		page, err := context.NewPage()
+		require.NoError(t, err)
+		_, err = page.Goto("http://non-existent.com/target.html")
+		require.NoError(t, err)
+		require.NoError(t, expect.Page(page).ToHaveTitle(title))
+		require.NoError(t, context.Close())
+	}
+}
+
+func TestBrowserContextShouldRejectUnsupportedProxyScheme(t *testing.T) {
+	_, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		Proxy: &playwright.Proxy{Server: "ftp://127.0.0.1:21"},
+	})
+	require.ErrorContains(t, err, `unsupported proxy server scheme "ftp"`)
+}
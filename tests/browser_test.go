@@ -59,6 +59,20 @@ func TestBrowserNewPage(t *testing.T) {
 	require.Equal(t, 1, len(browser.Contexts()))
 }
 
+func TestBrowserContextsShouldDropClosedContexts(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	context2, err := browser.NewContext()
+	require.NoError(t, err)
+	contexts := browser.Contexts()
+	require.Equal(t, []playwright.BrowserContext{context, context2}, contexts)
+
+	require.NoError(t, context2.Close())
+	require.Equal(t, []playwright.BrowserContext{context}, browser.Contexts())
+	// slices returned earlier are snapshots and are not modified afterwards
+	require.Equal(t, []playwright.BrowserContext{context, context2}, contexts)
+}
+
 func TestBrowserNewPageWithExtraHTTPHeaders(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
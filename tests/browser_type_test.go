@@ -1,8 +1,12 @@
 package playwright_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -62,6 +66,70 @@ func TestBrowserTypeLaunchPersistentContext(t *testing.T) {
 	require.NoError(t, browser_context3.Close())
 }
 
+func TestBrowserTypeLaunchPersistentContextShouldPersistCookies(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	userDataDir := t.TempDir()
+	browserContext, err := browserType.LaunchPersistentContext(userDataDir, playwright.BrowserTypeLaunchPersistentContextOptions{
+		Viewport: &playwright.Size{Width: 400, Height: 300},
+	})
+	require.NoError(t, err)
+	require.Len(t, browserContext.Pages(), 1)
+	page := browserContext.Pages()[0]
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page.Evaluate("() => document.cookie = 'doSomethingOnlyOnce=true; expires=Fri, 31 Dec 9999 23:59:59 GMT'")
+	require.NoError(t, err)
+	width, err := page.Evaluate("() => window.innerWidth")
+	require.NoError(t, err)
+	require.Equal(t, 400, width)
+	require.NoError(t, browserContext.Close())
+
+	browserContext2, err := browserType.LaunchPersistentContext(userDataDir)
+	require.NoError(t, err)
+	defer browserContext2.Close()
+	page2 := browserContext2.Pages()[0]
+	_, err = page2.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	cookie, err := page2.Evaluate("() => document.cookie")
+	require.NoError(t, err)
+	require.Equal(t, "doSomethingOnlyOnce=true", cookie)
+	if browser := browserContext2.Browser(); browser != nil {
+		require.Contains(t, browser.Contexts(), browserContext2)
+	}
+}
+
+func TestBrowserTypeLaunchShouldRejectChannelWithExecutablePath(t *testing.T) {
+	_, err := browserType.Launch(playwright.BrowserTypeLaunchOptions{
+		Channel:        playwright.String("chrome"),
+		ExecutablePath: playwright.String("/usr/bin/chromium"),
+	})
+	require.ErrorContains(t, err, "cannot specify both channel and executablePath")
+	_, err = browserType.LaunchPersistentContext(t.TempDir(), playwright.BrowserTypeLaunchPersistentContextOptions{
+		Channel:        playwright.String("chrome"),
+		ExecutablePath: playwright.String("/usr/bin/chromium"),
+	})
+	require.ErrorContains(t, err, "cannot specify both channel and executablePath")
+}
+
+func TestBrowserTypeLaunchShouldPassArgsAndEnv(t *testing.T) {
+	if !isChromium {
+		t.Skip("--user-agent is a Chromium flag")
+	}
+	browser, err := browserType.Launch(playwright.BrowserTypeLaunchOptions{
+		Args:    []string{"--user-agent=playwright-go-args"},
+		Env:     map[string]string{"PLAYWRIGHT_GO_TEST": "1"},
+		Timeout: playwright.Float(30000),
+	})
+	require.NoError(t, err)
+	defer browser.Close()
+	page, err := browser.NewPage()
+	require.NoError(t, err)
+	userAgent, err := page.Evaluate("() => navigator.userAgent")
+	require.NoError(t, err)
+	require.Equal(t, "playwright-go-args", userAgent)
+}
+
 func TestBrowserTypeConnect(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -260,3 +328,36 @@ func TestBrowserTypeConnectOverCDPTwice(t *testing.T) {
 	require.Len(t, browser2.Contexts()[0].Pages(), 2)
 
 }
+
+func TestBrowserTypeConnectShouldStreamLargeDownload(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	// Larger than a single stream chunk so SaveAs has to read several times.
+	body := bytes.Repeat([]byte("0123456789abcdef"), 3*1024*1024/16+7)
+	server.SetRoute("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/octet-stream")
+		w.Header().Add("Content-Disposition", "attachment; filename=large.bin")
+		_, _ = w.Write(body)
+	})
+	remoteServer, err := newRemoteServer()
+	require.NoError(t, err)
+	defer remoteServer.Close()
+	browser, err := browserType.Connect(remoteServer.url)
+	require.NoError(t, err)
+	defer browser.Close()
+	page, err := browser.NewPage(playwright.BrowserNewPageOptions{
+		AcceptDownloads: playwright.Bool(true),
+	})
+	require.NoError(t, err)
+	require.NoError(t, page.SetContent(fmt.Sprintf(`<a href="%s/large">download</a>`, server.PREFIX)))
+	download, err := page.ExpectDownload(func() error {
+		return page.Locator("a").Click()
+	})
+	require.NoError(t, err)
+	target := filepath.Join(t.TempDir(), "nested", download.SuggestedFilename())
+	require.NoError(t, download.SaveAs(target))
+	saved, err := os.ReadFile(target)
+	require.NoError(t, err)
+	require.Equal(t, len(body), len(saved))
+	require.True(t, bytes.Equal(body, saved))
+}
@@ -0,0 +1,43 @@
+//go:build !windows
+
+package playwright_test
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrowserTypeConnectKeepAliveShouldDetectUnresponsiveServer(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	remoteServer, err := newRemoteServer()
+	require.NoError(t, err)
+	defer remoteServer.Close()
+	browser, err := browserType.Connect(remoteServer.url, playwright.BrowserTypeConnectOptions{
+		KeepAliveInterval: playwright.Float(200),
+		KeepAliveTimeout:  playwright.Float(200),
+	})
+	require.NoError(t, err)
+	disconnected := make(chan struct{})
+	browser.OnDisconnected(func(playwright.Browser) {
+		close(disconnected)
+	})
+	page, err := browser.NewPage()
+	require.NoError(t, err)
+	// Answered keepalives keep the connection open.
+	time.Sleep(time.Second)
+	require.True(t, browser.IsConnected())
+
+	require.NoError(t, remoteServer.cmd.Process.Signal(syscall.SIGSTOP))
+	select {
+	case <-disconnected:
+	case <-time.After(10 * time.Second):
+		t.Fatal("disconnected was not emitted")
+	}
+	_, err = page.Evaluate("1 + 1")
+	require.Error(t, err)
+}
@@ -2,6 +2,7 @@ package playwright_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -14,7 +15,7 @@ func TestCDPSessionSend(t *testing.T) {
 		require.NoError(t, err)
 		result, err := cdpSession.Send("Target.getTargets", nil)
 		require.NoError(t, err)
-		targetInfos := result.(map[string]interface{})["targetInfos"].([]interface{})
+		targetInfos := result["targetInfos"].([]interface{})
 		require.GreaterOrEqual(t, len(targetInfos), 1)
 	} else {
 		require.Error(t, err)
@@ -40,6 +41,35 @@ func TestCDPSessionOn(t *testing.T) {
 	}
 }
 
+func TestCDPSessionOnShouldDecodeEventParams(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	cdpSession, err := page.Context().NewCDPSession(page)
+	if isChromium {
+		require.NoError(t, err)
+		result, err := cdpSession.Send("Runtime.enable", nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{}, result)
+		messages := make(chan map[string]interface{}, 1)
+		cdpSession.On("Runtime.consoleAPICalled", func(params map[string]interface{}) {
+			messages <- params
+		})
+		_, err = page.Evaluate(`console.log("hello")`)
+		require.NoError(t, err)
+		select {
+		case params := <-messages:
+			require.Equal(t, "log", params["type"])
+			args := params["args"].([]interface{})
+			require.Equal(t, "hello", args[0].(map[string]interface{})["value"])
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for Runtime.consoleAPICalled")
+		}
+		require.NoError(t, cdpSession.Detach())
+	} else {
+		require.Error(t, err)
+	}
+}
+
 func TestCDPSessionDetach(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -47,6 +77,8 @@ func TestCDPSessionDetach(t *testing.T) {
 	if isChromium {
 		require.NoError(t, err)
 		require.NoError(t, cdpSession.Detach())
+		_, err = cdpSession.Send("Target.getTargets", nil)
+		require.Error(t, err)
 	} else {
 		require.Error(t, err)
 	}
@@ -160,3 +160,95 @@ func TestConsoleShouldHaveLocationForConsoleAPICalls(t *testing.T) {
 	require.Equal(t, server.PREFIX+"/consolelog.html", message.Location().URL)
 	require.Equal(t, 7, message.Location().LineNumber)
 }
+
+func TestCollectConsoleMessagesShouldFilterByType(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	errors := playwright.CollectConsoleMessages(page, "error")
+	all := playwright.CollectConsoleMessages(page)
+	_, err := page.ExpectConsoleMessage(func() error {
+		_, err := page.Evaluate(`() => {
+			console.log("one");
+			console.error("two");
+			console.warn("three");
+			console.error("four");
+			console.log("done");
+		}`)
+		return err
+	}, playwright.PageExpectConsoleMessageOptions{
+		Predicate: func(message playwright.ConsoleMessage) bool {
+			return message.Text() == "done"
+		},
+	})
+	require.NoError(t, err)
+
+	texts := func(messages []playwright.ConsoleMessage) []string {
+		out := []string{}
+		for _, message := range messages {
+			out = append(out, message.Type()+" "+message.Text())
+		}
+		return out
+	}
+	require.Equal(t, []string{"error two", "error four"}, texts(errors.Messages()))
+	require.Equal(t, []string{"log one", "error two", "warning three", "error four", "log done"}, texts(all.Messages()))
+
+	require.Len(t, errors.Drain(), 2)
+	require.Empty(t, errors.Messages())
+	errors.Stop()
+	_, err = page.ExpectConsoleMessage(func() error {
+		_, err := page.Evaluate(`() => console.error("five")`)
+		return err
+	})
+	require.NoError(t, err)
+	require.Empty(t, errors.Messages())
+	require.Len(t, all.Messages(), 6)
+}
+
+func TestCollectConsoleMessagesShouldBeSafeToDrainConcurrently(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	collector := playwright.CollectConsoleMessages(context, "log")
+	done := make(chan struct{})
+	drained := make(chan int)
+	go func() {
+		count := 0
+		for {
+			select {
+			case <-done:
+				drained <- count + len(collector.Drain())
+				return
+			default:
+				count += len(collector.Drain())
+			}
+		}
+	}()
+	_, err := page.ExpectConsoleMessage(func() error {
+		_, err := page.Evaluate(`() => { for (let i = 0; i < 100; i++) console.log(i); }`)
+		return err
+	}, playwright.PageExpectConsoleMessageOptions{
+		Predicate: func(message playwright.ConsoleMessage) bool {
+			return message.Text() == "99"
+		},
+	})
+	require.NoError(t, err)
+	close(done)
+	require.Equal(t, 100, <-drained)
+}
+
+func TestConsoleMessageArgsShouldExposeElementHandles(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div id="logged">hi</div>`))
+	message, err := page.ExpectConsoleMessage(func() error {
+		_, err := page.Evaluate(`() => console.log("element", document.querySelector("#logged"))`)
+		return err
+	})
+	require.NoError(t, err)
+	args := message.Args()
+	require.Len(t, args, 2)
+	element := args[1].AsElement()
+	require.NotNil(t, element)
+	text, err := element.TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "hi", text)
+}
@@ -0,0 +1,148 @@
+package playwright_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverageJSShouldWork(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	err := page.Coverage().StartJSCoverage()
+	if !isChromium {
+		require.ErrorContains(t, err, "only supported in Chromium")
+		return
+	}
+	require.NoError(t, err)
+	_, err = page.Goto(server.PREFIX + "/jscoverage/simple.html")
+	require.NoError(t, err)
+	entries, err := page.Coverage().StopJSCoverage()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Contains(t, entries[0].URL, "/jscoverage/simple.html")
+	require.Contains(t, entries[0].Source, "function foo()")
+	require.NotEmpty(t, entries[0].Functions)
+	names := []string{}
+	for _, fn := range entries[0].Functions {
+		names = append(names, fn.FunctionName)
+		require.NotEmpty(t, fn.Ranges)
+	}
+	require.Contains(t, names, "foo")
+	require.Contains(t, names, "bar")
+}
+
+func TestCoverageJSShouldIgnoreAnonymousScripts(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("coverage is only supported in Chromium")
+	}
+	require.NoError(t, page.Coverage().StartJSCoverage())
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page.Evaluate(`() => console.log(1)`)
+	require.NoError(t, err)
+	entries, err := page.Coverage().StopJSCoverage()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestCoverageJSShouldResetOnNavigation(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("coverage is only supported in Chromium")
+	}
+	require.NoError(t, page.Coverage().StartJSCoverage())
+	_, err := page.Goto(server.PREFIX + "/jscoverage/multiple.html")
+	require.NoError(t, err)
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	entries, err := page.Coverage().StopJSCoverage()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	require.NoError(t, page.Coverage().StartJSCoverage(playwright.CoverageStartJSCoverageOptions{
+		ResetOnNavigation: playwright.Bool(false),
+	}))
+	_, err = page.Goto(server.PREFIX + "/jscoverage/multiple.html")
+	require.NoError(t, err)
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	entries, err = page.Coverage().StopJSCoverage()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestCoverageJSShouldKeepSourcesOfPreviousPages(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("coverage is only supported in Chromium")
+	}
+	require.NoError(t, page.Coverage().StartJSCoverage(playwright.CoverageStartJSCoverageOptions{
+		ResetOnNavigation: playwright.Bool(false),
+	}))
+	_, err := page.Goto(server.PREFIX + "/jscoverage/multiple.html")
+	require.NoError(t, err)
+	_, err = page.Goto(server.PREFIX + "/jscoverage/simple.html")
+	require.NoError(t, err)
+	entries, err := page.Coverage().StopJSCoverage()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	sources := map[string]string{}
+	for _, entry := range entries {
+		sources[entry.URL[strings.LastIndex(entry.URL, "/")+1:]] = entry.Source
+	}
+	require.Contains(t, sources["script1.js"], "console.log(3)")
+	require.NotEmpty(t, sources["script2.js"])
+	require.Contains(t, sources["simple.html"], "function foo()")
+}
+
+func TestCoverageJSShouldErrorWhenNotStarted(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Coverage().StopJSCoverage()
+	require.ErrorContains(t, err, "JSCoverage is not enabled")
+	_, err = page.Coverage().StopCSSCoverage()
+	require.ErrorContains(t, err, "CSSCoverage is not enabled")
+}
+
+func TestCoverageCSSShouldWork(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	err := page.Coverage().StartCSSCoverage()
+	if !isChromium {
+		require.ErrorContains(t, err, "only supported in Chromium")
+		return
+	}
+	require.NoError(t, err)
+	_, err = page.Goto(server.PREFIX + "/csscoverage/simple.html")
+	require.NoError(t, err)
+	entries, err := page.Coverage().StopCSSCoverage()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Contains(t, entries[0].URL, "/csscoverage/simple.html")
+	require.Equal(t, []playwright.CoverageRange{{StartOffset: 1, EndOffset: 22}}, entries[0].Ranges)
+	used := entries[0].Text[entries[0].Ranges[0].StartOffset:entries[0].Ranges[0].EndOffset]
+	require.Equal(t, "div { color: green; }", used)
+}
+
+func TestCoverageCSSShouldReportMultipleStylesheets(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	if !isChromium {
+		t.Skip("coverage is only supported in Chromium")
+	}
+	require.NoError(t, page.Coverage().StartCSSCoverage())
+	_, err := page.Goto(server.PREFIX + "/csscoverage/multiple.html")
+	require.NoError(t, err)
+	entries, err := page.Coverage().StopCSSCoverage()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Contains(t, entries[0].URL, "stylesheet1.css")
+	require.Contains(t, entries[1].URL, "stylesheet2.css")
+}
@@ -61,3 +61,60 @@ func TestDialogShouldWorkInPopup(t *testing.T) {
 	require.Equal(t, "hey?", d.Message())
 	require.Equal(t, d.Page(), popup)
 }
+
+func TestDialogShouldBeDismissedByDefault(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	result, err := page.Evaluate(`() => confirm('sure?')`)
+	require.NoError(t, err)
+	require.Equal(t, false, result)
+	result, err = page.Evaluate(`() => prompt('question?')`)
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
+
+func TestBrowserContextSetDialogPolicy(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	policy := playwright.DialogPolicy{
+		"confirm":      playwright.DialogActionAccept,
+		"beforeunload": playwright.DialogActionAccept,
+	}
+	context.SetDialogPolicy(policy)
+	// The context keeps its own copy of the policy.
+	policy["confirm"] = playwright.DialogActionDismiss
+	result, err := page.Evaluate(`() => confirm('sure?')`)
+	require.NoError(t, err)
+	require.Equal(t, true, result)
+	// Types missing from the policy are dismissed.
+	result, err = page.Evaluate(`() => prompt('question?', 'default')`)
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	// The policy still applies after the last handler is removed.
+	handler := func(dialog playwright.Dialog) {
+		require.NoError(t, dialog.Dismiss())
+	}
+	context.OnDialog(handler)
+	result, err = page.Evaluate(`() => confirm('sure?')`)
+	require.NoError(t, err)
+	require.Equal(t, false, result)
+	context.RemoveListener("dialog", handler)
+	result, err = page.Evaluate(`() => confirm('sure?')`)
+	require.NoError(t, err)
+	require.Equal(t, true, result)
+}
+
+func TestDialogShouldNotBeHandledTwice(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	errs := make(chan error, 1)
+	page.OnDialog(func(dialog playwright.Dialog) {
+		require.NoError(t, dialog.Accept())
+		errs <- dialog.Dismiss()
+	})
+	result, err := page.Evaluate(`() => confirm('sure?')`)
+	require.NoError(t, err)
+	require.Equal(t, true, result)
+	require.ErrorContains(t, <-errs, "already handled")
+}
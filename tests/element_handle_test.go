@@ -51,6 +51,52 @@ func TestElementHandleContentFrame(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, frame, page.Frames()[1])
 }
+
+func TestElementHandleContentFrameForNonIframes(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = utils.AttachFrame(page, "frame1", server.EMPTY_PAGE)
+	require.NoError(t, err)
+	frame := page.Frames()[1]
+	handle, err := frame.EvaluateHandle("document.body")
+	require.NoError(t, err)
+	contentFrame, err := handle.(playwright.ElementHandle).ContentFrame()
+	require.NoError(t, err)
+	require.Nil(t, contentFrame)
+}
+
+func TestElementHandleContentFrameNestedIframes(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.PREFIX + "/frames/nested-frames.html")
+	require.NoError(t, err)
+	outer, err := page.QuerySelector("iframe[name='2frames']")
+	require.NoError(t, err)
+	outerFrame, err := outer.ContentFrame()
+	require.NoError(t, err)
+	require.Equal(t, "2frames", outerFrame.Name())
+	require.Equal(t, page.MainFrame(), outerFrame.ParentFrame())
+
+	inner, err := outerFrame.QuerySelector("iframe[name='dos']")
+	require.NoError(t, err)
+	innerFrame, err := inner.ContentFrame()
+	require.NoError(t, err)
+	require.Equal(t, "dos", innerFrame.Name())
+	require.Equal(t, outerFrame, innerFrame.ParentFrame())
+
+	// the iframe element itself belongs to the frame that contains it
+	ownerFrame, err := inner.OwnerFrame()
+	require.NoError(t, err)
+	require.Equal(t, outerFrame, ownerFrame)
+
+	body, err := innerFrame.QuerySelector("body")
+	require.NoError(t, err)
+	ownerFrame, err = body.OwnerFrame()
+	require.NoError(t, err)
+	require.Equal(t, innerFrame, ownerFrame)
+}
 func TestElementHandleGetAttribute(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -60,10 +106,10 @@ func TestElementHandleGetAttribute(t *testing.T) {
 	require.NoError(t, err)
 	a1, err := handle.GetAttribute("name")
 	require.NoError(t, err)
-	require.Equal(t, "value", a1)
+	require.Equal(t, playwright.String("value"), a1)
 	a2, err := page.GetAttribute("#outer", "name")
 	require.NoError(t, err)
-	require.Equal(t, "value", a2)
+	require.Equal(t, playwright.String("value"), a2)
 }
 
 func TestElementHandleDispatchEvent(t *testing.T) {
@@ -216,7 +262,7 @@ func TestElementHandleQuerySelectorAll(t *testing.T) {
 	require.Equal(t, 2, len(elements))
 	className, err := elements[0].GetAttribute("class")
 	require.NoError(t, err)
-	require.Equal(t, "foobar", className)
+	require.Equal(t, playwright.String("foobar"), className)
 }
 
 func TestElementHandleEvalOnSelector(t *testing.T) {
@@ -575,3 +621,37 @@ func TestElementHandleSetChecked(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, isChecked.(bool))
 }
+
+func TestElementHandleQuerySelectorShouldQueryWithinElement(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+	<div class="item">outside</div>
+	<section>
+		<div class="item">first</div>
+		<div class="item">second</div>
+	</section>`))
+	container, err := page.QuerySelector("section")
+	require.NoError(t, err)
+
+	item, err := container.QuerySelector(".item")
+	require.NoError(t, err)
+	text, err := item.TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "first", text)
+
+	items, err := container.QuerySelectorAll(".item")
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	text, err = items[1].TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "second", text)
+
+	missing, err := container.QuerySelector(".missing")
+	require.NoError(t, err)
+	require.Nil(t, missing)
+	none, err := container.QuerySelectorAll(".missing")
+	require.NoError(t, err)
+	require.NotNil(t, none)
+	require.Len(t, none, 0)
+}
@@ -2,6 +2,7 @@ package playwright_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -132,6 +133,39 @@ func TestShouldReportDifferentFrameInstanceWhenFrameReattaches(t *testing.T) {
 	require.NotEqual(t, frame1, frame2)
 }
 
+func TestFrameOperationsShouldFailWhenDetached(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	frame, err := utils.AttachFrame(page, "frame1", server.EMPTY_PAGE)
+	require.NoError(t, err)
+
+	pending := make(chan error, 1)
+	go func() {
+		_, err := frame.WaitForSelector("#never")
+		pending <- err
+	}()
+	require.NoError(t, frame.Locator("body").WaitFor())
+	require.NoError(t, utils.DetachFrame(page, "frame1"))
+	require.True(t, frame.IsDetached())
+
+	var detached *playwright.FrameDetachedError
+	select {
+	case err := <-pending:
+		require.ErrorAs(t, err, &detached)
+	case <-time.After(5 * time.Second):
+		t.Fatal("pending operation did not fail after the frame was detached")
+	}
+
+	_, err = frame.Title()
+	require.ErrorAs(t, err, &detached)
+	require.Equal(t, server.EMPTY_PAGE, detached.URL)
+	require.EqualError(t, err, "frame was detached")
+	err = frame.Locator("body").Click()
+	require.ErrorAs(t, err, &detached)
+}
+
 func TestShouldSendEventsWhenFramesAreManipulatedDynamically(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -216,3 +250,188 @@ func TestFrameParent(t *testing.T) {
 	require.Equal(t, page.MainFrame(), frames[1].ParentFrame())
 	require.Equal(t, page.MainFrame(), frames[2].ParentFrame())
 }
+
+func TestFrameDetachShouldUpdateFrameTree(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	frame1, err := utils.AttachFrame(page, "frame1", server.EMPTY_PAGE+"?frame1")
+	require.NoError(t, err)
+	frame2, err := utils.AttachFrame(page, "frame2", server.PREFIX+"/frames/frame.html")
+	require.NoError(t, err)
+	require.Len(t, page.Frames(), 3)
+	require.Len(t, page.MainFrame().ChildFrames(), 2)
+
+	require.Equal(t, frame2, page.Frame(playwright.PageFrameOptions{URL: "**/frames/frame.html"}))
+	require.Equal(t, frame2, page.Frame(playwright.PageFrameOptions{URL: regexp.MustCompile(`frame\.html$`)}))
+	require.Equal(t, frame1, page.Frame(playwright.PageFrameOptions{URL: func(url string) bool {
+		// the main frame is at EMPTY_PAGE too, so frame1 has a URL of its own
+		return url == server.EMPTY_PAGE+"?frame1"
+	}}))
+	// a name is matched on its own, the URL is not consulted
+	require.Equal(t, frame1, page.Frame(playwright.PageFrameOptions{Name: playwright.String("frame1"), URL: "**/frame.html"}))
+
+	require.NoError(t, utils.DetachFrame(page, "frame1"))
+	require.True(t, frame1.IsDetached())
+	require.Nil(t, frame1.ParentFrame())
+	require.Equal(t, []playwright.Frame{page.MainFrame(), frame2}, page.Frames())
+	require.Equal(t, []playwright.Frame{frame2}, page.MainFrame().ChildFrames())
+	require.Nil(t, page.Frame(playwright.PageFrameOptions{Name: playwright.String("frame1")}))
+}
+
+func TestFrameSetContentTitleAndContent(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<title>top</title>`))
+	frame, err := utils.AttachFrame(page, "frame1", server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, frame.SetContent(`<title>inner</title><div>hello</div>`))
+
+	title, err := frame.Title()
+	require.NoError(t, err)
+	require.Equal(t, "inner", title)
+	title, err = page.Title()
+	require.NoError(t, err)
+	require.Equal(t, "top", title)
+
+	content, err := frame.Content()
+	require.NoError(t, err)
+	require.Contains(t, content, "<div>hello</div>")
+	content, err = page.Content()
+	require.NoError(t, err)
+	require.NotContains(t, content, "<div>hello</div>")
+}
+
+func TestFrameWaitForSelectorStates(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	frame, err := utils.AttachFrame(page, "frame1", server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, frame.SetContent(`<div id="hidden" style="display:none">hidden</div>`))
+
+	_, err = frame.Evaluate(`() => setTimeout(() => {
+		const div = document.createElement('div');
+		div.id = 'late';
+		div.textContent = 'late';
+		document.body.appendChild(div);
+	}, 100)`)
+	require.NoError(t, err)
+	//nolint:staticcheck
+	handle, err := frame.WaitForSelector("#late", playwright.FrameWaitForSelectorOptions{
+		State: playwright.WaitForSelectorStateVisible,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, handle)
+	text, err := handle.TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "late", text)
+
+	//nolint:staticcheck
+	handle, err = frame.WaitForSelector("#hidden", playwright.FrameWaitForSelectorOptions{
+		State: playwright.WaitForSelectorStateAttached,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, handle)
+
+	//nolint:staticcheck
+	handle, err = frame.WaitForSelector("#hidden", playwright.FrameWaitForSelectorOptions{
+		State: playwright.WaitForSelectorStateHidden,
+	})
+	require.NoError(t, err)
+	require.Nil(t, handle)
+
+	_, err = frame.Evaluate(`() => setTimeout(() => document.querySelector('#late').remove(), 100)`)
+	require.NoError(t, err)
+	//nolint:staticcheck
+	handle, err = frame.WaitForSelector("#late", playwright.FrameWaitForSelectorOptions{
+		State: playwright.WaitForSelectorStateDetached,
+	})
+	require.NoError(t, err)
+	require.Nil(t, handle)
+}
+
+func TestFrameWaitForSelectorStrictAndTimeout(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<span>one</span><span>two</span>`))
+	//nolint:staticcheck
+	_, err := page.MainFrame().WaitForSelector("span", playwright.FrameWaitForSelectorOptions{
+		Strict: playwright.Bool(true),
+	})
+	require.ErrorContains(t, err, "strict mode violation")
+	//nolint:staticcheck
+	handle, err := page.WaitForSelector("span")
+	require.NoError(t, err)
+	require.NotNil(t, handle)
+	//nolint:staticcheck
+	_, err = page.WaitForSelector("#missing", playwright.PageWaitForSelectorOptions{
+		Timeout: playwright.Float(100),
+	})
+	require.ErrorIs(t, err, playwright.TimeoutError)
+}
+
+func TestFramePageShouldReturnSamePageInstance(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	child, err := utils.AttachFrame(page, "child", server.PREFIX+"/frames/frame.html")
+	require.NoError(t, err)
+
+	mainFrame := page.MainFrame()
+	require.Same(t, page, mainFrame.Page())
+	require.Same(t, page, child.Page())
+	require.Equal(t, server.EMPTY_PAGE, mainFrame.URL())
+	require.Equal(t, "", mainFrame.Name())
+	require.Equal(t, server.PREFIX+"/frames/frame.html", child.URL())
+	require.Equal(t, "child", child.Name())
+
+	// handlers registered through the back-reference fire for events of the original page
+	closed := make(chan bool, 1)
+	child.Page().OnClose(func(p playwright.Page) {
+		closed <- p == page
+	})
+	require.NoError(t, page.Close())
+	require.True(t, <-closed)
+}
+
+func TestFrameEvaluateSurfaceShouldMatchPage(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	frame, err := utils.AttachFrame(page, "frame1", server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, frame.SetContent(`<section><div class="item">a</div><div class="item">b</div></section>`))
+
+	result, err := frame.Evaluate(`([a, b]) => a + b`, []int{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, 3, result)
+
+	handle, err := frame.EvaluateHandle(`() => document.querySelector("section")`)
+	require.NoError(t, err)
+	section := handle.AsElement()
+	require.NotNil(t, section)
+	result, err = frame.Evaluate(`section => section.childElementCount`, section)
+	require.NoError(t, err)
+	require.Equal(t, 2, result)
+
+	result, err = frame.EvalOnSelector(".item >> nth=1", `(e, suffix) => e.textContent + suffix`, "!")
+	require.NoError(t, err)
+	require.Equal(t, "b!", result)
+	result, err = frame.EvalOnSelectorAll(".item", `(items, sep) => items.map(e => e.textContent).join(sep)`, ",")
+	require.NoError(t, err)
+	require.Equal(t, "a,b", result)
+
+	_, err = frame.EvalOnSelector(".missing", `e => e.textContent`, nil)
+	require.ErrorContains(t, err, `Failed to find element matching selector ".missing"`)
+	result, err = frame.EvalOnSelectorAll(".missing", `items => items.length`)
+	require.NoError(t, err)
+	require.Equal(t, 0, result)
+
+	// the frame evaluates in its own document, not in the one of the main frame
+	result, err = page.EvalOnSelectorAll(".item", `items => items.length`)
+	require.NoError(t, err)
+	require.Equal(t, 0, result)
+}
@@ -87,7 +87,7 @@ var DEFAULT_CONTEXT_OPTIONS = playwright.BrowserNewContextOptions{
 	HasTouch:        playwright.Bool(true),
 }
 
-func BeforeEach(t *testing.T, contextOptions ...playwright.BrowserNewContextOptions) {
+func BeforeEach(t testing.TB, contextOptions ...playwright.BrowserNewContextOptions) {
 	if len(contextOptions) == 1 {
 		newContextWithOptions(t, contextOptions[0])
 		return
@@ -95,7 +95,7 @@ func BeforeEach(t *testing.T, contextOptions ...playwright.BrowserNewContextOpti
 	newContextWithOptions(t, DEFAULT_CONTEXT_OPTIONS)
 }
 
-func newContextWithOptions(t *testing.T, contextOptions playwright.BrowserNewContextOptions) {
+func newContextWithOptions(t testing.TB, contextOptions playwright.BrowserNewContextOptions) {
 	var err error
 	context, err = browser.NewContext(contextOptions)
 	require.NoError(t, err)
@@ -111,7 +111,7 @@ func Asset(path string) string {
 	return filepath.Join(cwd, "assets", path)
 }
 
-func AfterEach(t *testing.T, closeContext ...bool) {
+func AfterEach(t testing.TB, closeContext ...bool) {
 	if len(closeContext) == 0 {
 		if err := context.Close(); err != nil {
 			t.Errorf("could not close context: %v", err)
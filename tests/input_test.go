@@ -58,6 +58,37 @@ func TestMouseUp(t *testing.T) {
 	require.True(t, result.(bool))
 }
 
+func TestMouseDownUpShouldRespectButtonAndClickCount(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.SetContent(`<div style="width: 500px; height: 500px;"></div>`))
+	_, err = page.Evaluate(`() => {
+		window['events'] = [];
+		for (const type of ['mousedown', 'mouseup'])
+			document.addEventListener(type, e => window['events'].push([e.type, e.button, e.detail]));
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, page.Mouse().Move(50, 50, playwright.MouseMoveOptions{
+		Steps: playwright.Int(3),
+	}))
+	require.NoError(t, page.Mouse().Down(playwright.MouseDownOptions{
+		Button:     playwright.MouseButtonMiddle,
+		ClickCount: playwright.Int(2),
+	}))
+	require.NoError(t, page.Mouse().Up(playwright.MouseUpOptions{
+		Button:     playwright.MouseButtonMiddle,
+		ClickCount: playwright.Int(2),
+	}))
+	events, err := page.Evaluate("events")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		[]interface{}{"mousedown", 1, 2},
+		[]interface{}{"mouseup", 1, 2},
+	}, events)
+}
+
 func TestMouseClick(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -84,6 +115,59 @@ func TestMouseDblclick(t *testing.T) {
 	require.True(t, result.(bool))
 }
 
+func TestMouseClickShouldDrawOnCanvas(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<style>body { margin: 0 }</style>
+		<canvas width="200" height="200"></canvas>
+		<script>
+			const canvas = document.querySelector('canvas');
+			const ctx = canvas.getContext('2d');
+			window.clicks = [];
+			canvas.addEventListener('click', e => {
+				window.clicks.push([e.offsetX, e.offsetY, e.button, e.detail]);
+				ctx.fillStyle = 'red';
+				ctx.fillRect(e.offsetX - 2, e.offsetY - 2, 5, 5);
+			});
+			canvas.addEventListener('contextmenu', e => e.preventDefault());
+			canvas.addEventListener('auxclick', e => {
+				window.clicks.push([e.offsetX, e.offsetY, e.button, e.detail]);
+				ctx.fillStyle = 'blue';
+				ctx.fillRect(e.offsetX - 2, e.offsetY - 2, 5, 5);
+			});
+		</script>`))
+	mouse := page.Mouse()
+	require.NoError(t, mouse.Click(20, 30))
+	require.NoError(t, mouse.Click(100, 100, playwright.MouseClickOptions{
+		Delay: playwright.Float(20),
+	}))
+	require.NoError(t, mouse.Click(150, 40, playwright.MouseClickOptions{
+		Button: playwright.MouseButtonRight,
+	}))
+	require.NoError(t, mouse.Dblclick(60, 160))
+
+	clicks, err := page.Evaluate(`window.clicks`)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		[]interface{}{20, 30, 0, 1},
+		[]interface{}{100, 100, 0, 1},
+		[]interface{}{150, 40, 2, 1},
+		[]interface{}{60, 160, 0, 1},
+		[]interface{}{60, 160, 0, 2},
+	}, clicks)
+	pixels, err := page.Evaluate(`points => points.map(([x, y]) => {
+		const [r, g, b] = document.querySelector('canvas').getContext('2d').getImageData(x, y, 1, 1).data;
+		return [r, g, b];
+	})`, [][]int{{20, 30}, {150, 40}, {10, 10}})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		[]interface{}{255, 0, 0},
+		[]interface{}{0, 0, 255},
+		[]interface{}{0, 0, 0},
+	}, pixels)
+}
+
 func TestMouseWheel(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -126,6 +210,43 @@ func TestKeyboardUp(t *testing.T) {
 	require.True(t, result.(bool))
 }
 
+func TestKeyboardDownShouldHoldShiftForClicks(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<ul>
+			<li>one</li><li>two</li><li>three</li><li>four</li><li>five</li>
+		</ul>
+		<script>
+			let anchor = null;
+			const items = [...document.querySelectorAll('li')];
+			for (const item of items) {
+				item.addEventListener('click', e => {
+					const index = items.indexOf(item);
+					if (!e.shiftKey || anchor === null)
+						anchor = index;
+					const [from, to] = [Math.min(anchor, index), Math.max(anchor, index)];
+					items.forEach((it, i) => it.classList.toggle('selected', i >= from && i <= to));
+				});
+			}
+		</script>`))
+	items := page.Locator("li")
+	require.NoError(t, items.Nth(1).Click())
+	require.NoError(t, page.Keyboard().Down("Shift"))
+	require.NoError(t, items.Nth(3).Click())
+	require.NoError(t, items.Nth(2).Click())
+	require.NoError(t, items.Nth(3).Click())
+	require.NoError(t, page.Keyboard().Up("Shift"))
+	selected, err := page.Locator("li.selected").AllTextContents()
+	require.NoError(t, err)
+	require.Equal(t, []string{"two", "three", "four"}, selected)
+
+	require.NoError(t, items.Nth(4).Click())
+	selected, err = page.Locator("li.selected").AllTextContents()
+	require.NoError(t, err)
+	require.Equal(t, []string{"five"}, selected)
+}
+
 func TestKeyboardInsertText(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -152,6 +273,40 @@ func TestKeyboardType(t *testing.T) {
 	require.True(t, result.(bool))
 }
 
+func TestKeyboardPressWithModifiers(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<input>`))
+	_, err := page.Evaluate(`() => {
+		window['events'] = [];
+		const input = document.querySelector('input');
+		for (const type of ['keydown', 'keyup'])
+			input.addEventListener(type, e => window['events'].push([e.type, e.key, e.ctrlKey, e.shiftKey, e.altKey, e.metaKey]));
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("input").Focus())
+	require.NoError(t, page.Keyboard().PressWithModifiers("K", []playwright.KeyboardModifier{
+		*playwright.KeyboardModifierControl,
+		*playwright.KeyboardModifierShift,
+		*playwright.KeyboardModifierAlt,
+		*playwright.KeyboardModifierMeta,
+	}))
+	events, err := page.Evaluate("events")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		[]interface{}{"keydown", "Control", true, false, false, false},
+		[]interface{}{"keydown", "Shift", true, true, false, false},
+		[]interface{}{"keydown", "Alt", true, true, true, false},
+		[]interface{}{"keydown", "Meta", true, true, true, true},
+		[]interface{}{"keydown", "K", true, true, true, true},
+		[]interface{}{"keyup", "K", true, true, true, true},
+		[]interface{}{"keyup", "Meta", true, true, true, false},
+		[]interface{}{"keyup", "Alt", true, true, false, false},
+		[]interface{}{"keyup", "Shift", true, false, false, false},
+		[]interface{}{"keyup", "Control", false, false, false, false},
+	}, events)
+}
+
 func TestElementHandleType(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -224,3 +379,13 @@ func TestTouchscreenTap(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, result.(bool))
 }
+
+func TestTouchscreenTapShouldRequireHasTouch(t *testing.T) {
+	BeforeEach(t, playwright.BrowserNewContextOptions{
+		HasTouch: playwright.Bool(false),
+	})
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<button onclick="window.clicked=true" style="width: 500px; height: 500px;"/>`))
+	require.ErrorContains(t, page.Touchscreen().Tap(100.5, 100.5), "hasTouch must be enabled")
+	require.ErrorContains(t, page.Locator("button").Tap(), "hasTouch must be enabled")
+}
@@ -3,6 +3,7 @@ package playwright_test
 import (
 	"testing"
 
+	"github.com/playwright-community/playwright-go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -46,6 +47,50 @@ func TestJSHandleGetProperties(t *testing.T) {
 	require.Equal(t, 3, v1)
 }
 
+func TestJSHandleGetPropertiesShouldReturnElementHandles(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<section>hello</section>`))
+	handle, err := page.EvaluateHandle(`() => ({ body: document.body, section: document.querySelector('section'), n: 1 })`)
+	require.NoError(t, err)
+	properties, err := handle.GetProperties()
+	require.NoError(t, err)
+	require.Len(t, properties, 3)
+	require.Nil(t, properties["n"].AsElement())
+	section := properties["section"].AsElement()
+	require.NotNil(t, section)
+	text, err := section.TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "hello", text)
+
+	body, err := handle.GetProperty("body")
+	require.NoError(t, err)
+	require.NotNil(t, body.AsElement())
+}
+
+func TestJSHandleJSONValueAs(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	handle, err := page.EvaluateHandle(`() => ({ name: "cart", count: 2, items: [{ sku: "a", price: 1.5 }, { sku: "b", price: 3 }] })`)
+	require.NoError(t, err)
+	type item struct {
+		SKU   string  `json:"sku"`
+		Price float64 `json:"price"`
+	}
+	var cart struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+		Items []item `json:"items"`
+	}
+	require.NoError(t, handle.JSONValueAs(&cart))
+	require.Equal(t, "cart", cart.Name)
+	require.Equal(t, 2, cart.Count)
+	require.Equal(t, []item{{SKU: "a", Price: 1.5}, {SKU: "b", Price: 3}}, cart.Items)
+
+	var wrongType []string
+	require.ErrorContains(t, handle.JSONValueAs(&wrongType), "could not unmarshal JSON value")
+}
+
 func TestJSHandleEvaluate(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -81,6 +126,55 @@ func TestJSHandleEvaluateHandle(t *testing.T) {
 	require.Equal(t, value, 2)
 }
 
+func TestJSHandleEvaluateHandleShouldReturnElementHandles(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div><span>one</span></div>`))
+	divHandle, err := page.Locator("div").EvaluateHandle(`div => div`, nil)
+	require.NoError(t, err)
+	div, ok := divHandle.(playwright.ElementHandle)
+	require.True(t, ok)
+	spanHandle, err := div.EvaluateHandle(`div => div.firstElementChild`)
+	require.NoError(t, err)
+	require.NotNil(t, spanHandle.AsElement())
+
+	// handles passed back into evaluate reconnect to the same objects on the page
+	result, err := page.Evaluate(`([div, span]) => div.contains(span) && span.textContent`, []interface{}{divHandle, spanHandle})
+	require.NoError(t, err)
+	require.Equal(t, "one", result)
+}
+
+func TestPageEvaluateShouldAcceptNestedElementHandles(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div>one</div><span>two</span><p>three</p>`))
+	handles, err := page.QuerySelectorAll("div, span, p")
+	require.NoError(t, err)
+	require.Len(t, handles, 3)
+
+	tagNames, err := page.Evaluate(`elements => elements.map(e => e.tagName)`, handles)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"DIV", "SPAN", "P"}, tagNames)
+
+	type group struct {
+		Label    string                     `json:"label"`
+		Elements []playwright.ElementHandle `json:"elements"`
+	}
+	result, err := page.Evaluate(`g => g.label + ':' + g.elements.map(e => e.textContent).join(',')`, group{
+		Label:    "texts",
+		Elements: handles[1:],
+	})
+	require.NoError(t, err)
+	require.Equal(t, "texts:two,three", result)
+
+	result, err = page.Evaluate(`m => m.first.contains(m.rest[0]) || m.rest.length`, map[string]interface{}{
+		"first": handles[0],
+		"rest":  []playwright.ElementHandle{handles[2]},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, result)
+}
+
 func TestJSHandleTypeParsing(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
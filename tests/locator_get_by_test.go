@@ -47,6 +47,23 @@ func TestGetByText(t *testing.T) {
 	require.NoError(t, expect.Locator(page.Locator("div").GetByText("yo")).ToHaveCount(1))
 }
 
+func TestGetByTextIgnoreDiacritics(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent("<div>Un café crème</div><div>Cafe\u0301</div><div>cafetière</div>"))
+	require.NoError(t, expect.Locator(page.GetByText("cafe")).ToHaveCount(2))
+	require.NoError(t, expect.Locator(page.GetByText("cafe", playwright.PageGetByTextOptions{
+		IgnoreDiacritics: playwright.Bool(true),
+	})).ToHaveCount(3))
+	require.NoError(t, expect.Locator(page.GetByText("Cafe", playwright.PageGetByTextOptions{
+		Exact:            playwright.Bool(true),
+		IgnoreDiacritics: playwright.Bool(true),
+	})).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("body").GetByText(regexp.MustCompile(`creme$`), playwright.LocatorGetByTextOptions{
+		IgnoreDiacritics: playwright.Bool(true),
+	})).ToHaveCount(1))
+}
+
 func TestGetByLabel(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -138,3 +155,23 @@ func TestGetByRole(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, count)
 }
+
+func TestGetByRoleNormalizeWhitespace(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<button>Send
+		message</button><input type="checkbox" checked aria-label="Remember me">`))
+
+	require.NoError(t, expect.Locator(page.GetByRole("button", playwright.PageGetByRoleOptions{
+		Name:                "Send\n      message ",
+		Exact:               playwright.Bool(true),
+		NormalizeWhitespace: playwright.Bool(true),
+	})).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.GetByRole("checkbox", playwright.PageGetByRoleOptions{
+		Checked: playwright.Bool(true),
+		Name:    "remember",
+	})).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.GetByRole("checkbox", playwright.PageGetByRoleOptions{
+		Checked: playwright.Bool(false),
+	})).ToHaveCount(0))
+}
@@ -3,7 +3,10 @@ package playwright_test
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/playwright-community/playwright-go"
 	"github.com/stretchr/testify/require"
@@ -54,10 +57,65 @@ func TestLocatorGetAttribute(t *testing.T) {
 
 	result, err := page.Locator("#outer").GetAttribute("name")
 	require.NoError(t, err)
-	require.Equal(t, "value", result)
+	require.Equal(t, playwright.String("value"), result)
 	result, err = page.Locator("#outer").GetAttribute("foo")
 	require.NoError(t, err)
-	require.Empty(t, result)
+	require.Nil(t, result)
+}
+
+func TestTextExtractionParity(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div id="target" data-empty="" title="hi"><b>bold</b> text<span hidden>hidden</span></div>`))
+	timeout := playwright.Float(1000)
+
+	//nolint:staticcheck
+	title, err := page.GetAttribute("#target", "title", playwright.PageGetAttributeOptions{Timeout: timeout})
+	require.NoError(t, err)
+	require.Equal(t, playwright.String("hi"), title)
+	//nolint:staticcheck
+	empty, err := page.MainFrame().GetAttribute("#target", "data-empty", playwright.FrameGetAttributeOptions{Timeout: timeout})
+	require.NoError(t, err)
+	require.Equal(t, playwright.String(""), empty)
+	missing, err := page.Locator("#target").GetAttribute("missing", playwright.LocatorGetAttributeOptions{Timeout: timeout})
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	//nolint:staticcheck
+	innerHTML, err := page.InnerHTML("#target", playwright.PageInnerHTMLOptions{Timeout: timeout})
+	require.NoError(t, err)
+	//nolint:staticcheck
+	frameInnerHTML, err := page.MainFrame().InnerHTML("#target", playwright.FrameInnerHTMLOptions{Timeout: timeout})
+	require.NoError(t, err)
+	locatorInnerHTML, err := page.Locator("#target").InnerHTML(playwright.LocatorInnerHTMLOptions{Timeout: timeout})
+	require.NoError(t, err)
+	require.Equal(t, `<b>bold</b> text<span hidden="">hidden</span>`, innerHTML)
+	require.Equal(t, innerHTML, frameInnerHTML)
+	require.Equal(t, innerHTML, locatorInnerHTML)
+
+	//nolint:staticcheck
+	innerText, err := page.InnerText("#target", playwright.PageInnerTextOptions{Timeout: timeout})
+	require.NoError(t, err)
+	//nolint:staticcheck
+	frameInnerText, err := page.MainFrame().InnerText("#target", playwright.FrameInnerTextOptions{Timeout: timeout})
+	require.NoError(t, err)
+	locatorInnerText, err := page.Locator("#target").InnerText(playwright.LocatorInnerTextOptions{Timeout: timeout})
+	require.NoError(t, err)
+	require.Equal(t, "bold text", innerText)
+	require.Equal(t, innerText, frameInnerText)
+	require.Equal(t, innerText, locatorInnerText)
+
+	//nolint:staticcheck
+	textContent, err := page.TextContent("#target", playwright.PageTextContentOptions{Timeout: timeout})
+	require.NoError(t, err)
+	//nolint:staticcheck
+	frameTextContent, err := page.MainFrame().TextContent("#target", playwright.FrameTextContentOptions{Timeout: timeout})
+	require.NoError(t, err)
+	locatorTextContent, err := page.Locator("#target").TextContent(playwright.LocatorTextContentOptions{Timeout: timeout})
+	require.NoError(t, err)
+	require.Equal(t, "bold texthidden", textContent)
+	require.Equal(t, textContent, frameTextContent)
+	require.Equal(t, textContent, locatorTextContent)
 }
 
 func TestLocatorInnerHTML(t *testing.T) {
@@ -94,6 +152,30 @@ func TestLocatorInputValue(t *testing.T) {
 	require.Equal(t, "input value", result)
 }
 
+func TestLocatorInputValueShouldWorkForFormControls(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<textarea>multi
+line</textarea>
+		<select><option value="a">A</option><option value="b" selected>B</option></select>
+		<input value="">
+		<div>not a control</div>
+	`))
+	value, err := page.Locator("textarea").InputValue()
+	require.NoError(t, err)
+	require.Equal(t, "multi\nline", value)
+	value, err = page.Locator("select").InputValue()
+	require.NoError(t, err)
+	require.Equal(t, "b", value)
+	value, err = page.Locator("input").InputValue()
+	require.NoError(t, err)
+	require.Equal(t, "", value)
+
+	_, err = page.Locator("div").InputValue()
+	require.ErrorContains(t, err, "Node is not an <input>, <textarea> or <select> element")
+}
+
 func TestLocatorIsChecked(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -318,6 +400,37 @@ func TestLocatorSelectOption(t *testing.T) {
 	require.ElementsMatch(t, []string{"foo"}, result)
 }
 
+func TestLocatorSelectOptionShouldReplaceMultiSelection(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.PREFIX + "/input/select.html")
+	require.NoError(t, err)
+	_, err = page.Evaluate(`() => makeMultiple()`)
+	require.NoError(t, err)
+	selectLocator := page.Locator("select")
+
+	values := []string{"blue", "green"}
+	result, err := selectLocator.SelectOption(playwright.SelectOptionValues{Values: &values})
+	require.NoError(t, err)
+	require.Equal(t, []string{"blue", "green"}, result)
+
+	labels := []string{"Red"}
+	indexes := []int{7}
+	//nolint:staticcheck
+	option, err := page.QuerySelector("option[value=black]")
+	require.NoError(t, err)
+	result, err = selectLocator.SelectOption(playwright.SelectOptionValues{
+		Labels:   &labels,
+		Indexes:  &indexes,
+		Elements: &[]playwright.ElementHandle{option},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"black", "red", "magenta"}, result)
+	selected, err := page.Evaluate(`result.onChange`)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []interface{}{"black", "red", "magenta"}, selected)
+}
+
 func TestLocatorTextContent(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -408,6 +521,71 @@ func TestLocatorsShouldReturnBoundingBox(t *testing.T) {
 	}, box)
 }
 
+func TestLocatorsBoundingBoxShouldReturnNilForHiddenElements(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div style="display: none">hidden</div>`))
+	box, err := page.Locator("div").BoundingBox()
+	require.NoError(t, err)
+	require.Nil(t, box)
+	//nolint:staticcheck
+	handle, err := page.QuerySelector("div")
+	require.NoError(t, err)
+	box, err = handle.BoundingBox()
+	require.NoError(t, err)
+	require.Nil(t, box)
+}
+
+func TestLocatorsBoundingBoxShouldBeRelativeToMainFrame(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetViewportSize(500, 500))
+	require.NoError(t, page.SetContent(`<style>body { margin: 0 }</style>
+		<iframe style="position: absolute; left: 30px; top: 40px; border: 0" srcdoc="<style>body { margin: 0 }</style><div style='margin: 10px 20px; width: 50px; height: 60px'></div>"></iframe>`))
+	box, err := page.FrameLocator("iframe").Locator("div").BoundingBox()
+	require.NoError(t, err)
+	require.Equal(t, &playwright.Rect{
+		X:      50,
+		Y:      50,
+		Width:  50,
+		Height: 60,
+	}, box)
+}
+
+func TestLocatorsSetCheckedShouldWork(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<input id="box" type="checkbox" onclick="window.clicks = (window.clicks || 0) + 1">
+		<input id="stuck" type="checkbox" onclick="event.preventDefault()">
+		<div id="div">not a checkbox</div>
+	`))
+	box := page.Locator("#box")
+	require.NoError(t, box.SetChecked(true))
+	checked, err := box.IsChecked()
+	require.NoError(t, err)
+	require.True(t, checked)
+	// already in the desired state: no click happens
+	require.NoError(t, box.SetChecked(true))
+	clicks, err := page.Evaluate(`window.clicks`)
+	require.NoError(t, err)
+	require.Equal(t, 1, clicks)
+
+	require.NoError(t, box.SetChecked(false, playwright.LocatorSetCheckedOptions{
+		Trial: playwright.Bool(true),
+	}))
+	checked, err = box.IsChecked()
+	require.NoError(t, err)
+	require.True(t, checked)
+	require.NoError(t, box.SetChecked(false))
+	checked, err = box.IsChecked()
+	require.NoError(t, err)
+	require.False(t, checked)
+
+	require.ErrorContains(t, page.Locator("#stuck").SetChecked(true), "Clicking the checkbox did not change its state")
+	require.ErrorContains(t, page.Locator("#div").SetChecked(true), "Not a checkbox or radio button")
+}
+
 func TestLocatorsCheckShouldWork(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -475,6 +653,35 @@ func TestLocatorsDispatchEventShouldWork(t *testing.T) {
 	require.Equal(t, "Clicked", ret)
 }
 
+func TestLocatorsDispatchEventShouldDispatchCustomEventsWithInit(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div>one</div><div>two</div>`))
+	_, err := page.Evaluate(`() => {
+		document.body.addEventListener('my-event', e => {
+			window['received'] = [e.type, e.target.textContent, e.bubbles, e.cancelable];
+		});
+	}`)
+	require.NoError(t, err)
+	// unknown event types are constructed as a plain Event, which only honours the base init properties
+	require.NoError(t, page.Locator("div").Last().DispatchEvent("my-event", map[string]interface{}{
+		"cancelable": false,
+	}))
+	received, err := page.Evaluate(`received`)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"my-event", "two", true, false}, received)
+
+	// options should be forwarded: the locator is strict and times out
+	err = page.Locator("div").DispatchEvent("my-event", nil, playwright.LocatorDispatchEventOptions{
+		Timeout: playwright.Float(500),
+	})
+	require.ErrorContains(t, err, "strict mode violation")
+	err = page.Locator("span").DispatchEvent("my-event", nil, playwright.LocatorDispatchEventOptions{
+		Timeout: playwright.Float(500),
+	})
+	require.ErrorIs(t, err, playwright.TimeoutError)
+}
+
 func TestLocatorsDragToShouldWork(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -526,6 +733,24 @@ func TestLocatorsShouldQueryExistingElements(t *testing.T) {
 	require.Equal(t, []string{"A", "B"}, results)
 }
 
+func TestLocatorElementHandleShouldRespectStrictModeAndTimeout(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div>A</div><div>B</div>`))
+	//nolint:staticcheck
+	_, err := page.Locator("div").ElementHandle()
+	require.ErrorContains(t, err, "strict mode violation")
+	//nolint:staticcheck
+	_, err = page.Locator("span").ElementHandle(playwright.LocatorElementHandleOptions{
+		Timeout: playwright.Float(100),
+	})
+	require.ErrorIs(t, err, playwright.TimeoutError)
+	//nolint:staticcheck
+	elements, err := page.Locator("span").ElementHandles()
+	require.NoError(t, err)
+	require.Empty(t, elements)
+}
+
 func TestLocatorsEvaluateAllShouldWork(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -535,6 +760,47 @@ func TestLocatorsEvaluateAllShouldWork(t *testing.T) {
 	require.Equal(t, []interface{}{"100", "10"}, content)
 }
 
+func TestLocatorsEvaluateAllShouldForwardArg(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<ul><li data-price="3">a</li><li data-price="4">b</li><li data-price="5">c</li></ul>`))
+	total, err := page.Locator("li").EvaluateAll(`(nodes, tax) => nodes.reduce((sum, n) => sum + Number(n.dataset.price), 0) + tax`, 10)
+	require.NoError(t, err)
+	require.Equal(t, 22, total)
+
+	empty, err := page.Locator("li.missing").EvaluateAll(`nodes => nodes.length`)
+	require.NoError(t, err)
+	require.Equal(t, 0, empty)
+}
+
+func TestLocatorFilterHasTextRegexpShouldNormalizeWhitespace(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div>first</div><div>Hello
+		    wrapped   world</div>`))
+	count, err := page.Locator("div").Filter(playwright.LocatorFilterOptions{
+		HasText: regexp.MustCompile(`hello wrapped world`),
+	}).Count()
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+
+	locator := page.Locator("div").Filter(playwright.LocatorFilterOptions{
+		HasText:             regexp.MustCompile(`(?i)hello wrapped world`),
+		NormalizeWhitespace: playwright.Bool(true),
+	})
+	require.Equal(t, `div >> internal:has-text=/hello\s+wrapped\s+world/i`, locator.ResolvedSelector())
+	count, err = locator.Count()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	count, err = page.Locator("div").Filter(playwright.LocatorFilterOptions{
+		HasNotText:          regexp.MustCompile(`Hello wrapped world`),
+		NormalizeWhitespace: playwright.Bool(true),
+	}).Count()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
 func TestShouldSupportLocatorFilter(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -627,3 +893,419 @@ func TestLocatorHighlightShoudWork(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, yes)
 }
+
+func TestLocatorResolvedSelectorShouldIncludeChainedFilters(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<div><span>hello</span><button>click</button></div>
+		<div><span>world</span></div>
+	`))
+	locator := page.Locator("div").Filter(playwright.LocatorFilterOptions{
+		HasText:    "hello",
+		HasNotText: "world",
+		Has:        page.Locator("button"),
+	}).Locator("span").Nth(0)
+	require.Equal(t,
+		`div >> internal:has-text="hello"i >> internal:has-not-text="world"i >> internal:has="button" >> span >> nth=0`,
+		locator.ResolvedSelector(),
+	)
+	require.NoError(t, expect.Locator(locator).ToHaveText("hello"))
+
+	chained := page.Locator("div").Locator(page.Locator("button").Or(page.Locator("span")))
+	require.Equal(t, `div >> internal:chain="button >> internal:or=\"span\""`, chained.ResolvedSelector())
+}
+
+func setupLocatorBenchmarkTable(b *testing.B, rows int) {
+	b.Helper()
+	_, err := page.Evaluate(`rows => {
+		document.body.innerHTML = '<table>' + Array.from({ length: rows }, (_, i) => '<tr><td>' + i + '</td></tr>').join('') + '</table>'
+	}`, rows)
+	require.NoError(b, err)
+}
+
+// BenchmarkLocatorCountThenNth resolves every row separately: 1 + rows round-trips per iteration.
+func BenchmarkLocatorCountThenNth(b *testing.B) {
+	BeforeEach(b)
+	defer AfterEach(b)
+	setupLocatorBenchmarkTable(b, 50)
+	rows := page.Locator("td")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		count, err := rows.Count()
+		require.NoError(b, err)
+		for j := 0; j < count; j++ {
+			_, err := rows.Nth(j).TextContent()
+			require.NoError(b, err)
+		}
+	}
+}
+
+// BenchmarkLocatorEvaluateAll batches the same work into a single round-trip per iteration.
+func BenchmarkLocatorEvaluateAll(b *testing.B) {
+	BeforeEach(b)
+	defer AfterEach(b)
+	setupLocatorBenchmarkTable(b, 50)
+	rows := page.Locator("td")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		texts, err := rows.EvaluateAll(`cells => cells.map(c => c.textContent)`)
+		require.NoError(b, err)
+		require.Len(b, texts, 50)
+	}
+}
+
+func TestLocatorTapShouldWork(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div style="width: 100px; height: 100px;"></div>`))
+	_, err := page.Evaluate(`() => {
+		window['taps'] = [];
+		document.querySelector('div').addEventListener('touchstart', e => {
+			const rect = e.target.getBoundingClientRect();
+			window['taps'].push([e.touches[0].clientX - rect.left, e.touches[0].clientY - rect.top]);
+		});
+	}`)
+	require.NoError(t, err)
+	locator := page.Locator("div")
+	require.NoError(t, locator.Tap(playwright.LocatorTapOptions{
+		Trial: playwright.Bool(true),
+	}))
+	require.NoError(t, locator.Tap(playwright.LocatorTapOptions{
+		Position: &playwright.Position{X: 10, Y: 20},
+	}))
+	taps, err := page.Evaluate("taps")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{[]interface{}{10, 20}}, taps)
+}
+
+func TestLocatorScrollIntoViewIfNeeded(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div style="height: 5000px;"></div><button>bottom</button>`))
+	require.NoError(t, page.Locator("button").ScrollIntoViewIfNeeded())
+	inView, err := page.Locator("button").Evaluate(`button => {
+		const rect = button.getBoundingClientRect();
+		return window.scrollY > 0 && rect.top >= 0 && rect.bottom <= window.innerHeight;
+	}`, nil)
+	require.NoError(t, err)
+	require.True(t, inView.(bool))
+
+	err = page.Locator("span").ScrollIntoViewIfNeeded(playwright.LocatorScrollIntoViewIfNeededOptions{
+		Timeout: playwright.Float(500),
+	})
+	require.ErrorIs(t, err, playwright.TimeoutError)
+}
+
+func TestLocatorStateChecksShouldNotWaitForStateChanges(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<button disabled>Submit</button><input type="checkbox" checked>`))
+	_, err := page.Evaluate(`() => setTimeout(() => document.querySelector('button').disabled = false, 1000)`)
+	require.NoError(t, err)
+
+	button := page.Locator("button")
+	start := time.Now()
+	enabled, err := button.IsEnabled()
+	require.NoError(t, err)
+	require.False(t, enabled)
+	disabled, err := button.IsDisabled()
+	require.NoError(t, err)
+	require.True(t, disabled)
+	editable, err := page.Locator("input").IsEditable()
+	require.NoError(t, err)
+	require.True(t, editable)
+	checked, err := page.Locator("input").IsChecked()
+	require.NoError(t, err)
+	require.True(t, checked)
+	// hidden and visible resolve immediately, even when nothing matches
+	visible, err := page.Locator("span").IsVisible()
+	require.NoError(t, err)
+	require.False(t, visible)
+	hidden, err := page.Locator("span").IsHidden()
+	require.NoError(t, err)
+	require.True(t, hidden)
+	require.Less(t, time.Since(start), time.Second)
+
+	require.NoError(t, expect.Locator(button).ToBeEnabled())
+	enabled, err = button.IsEnabled(playwright.LocatorIsEnabledOptions{
+		Timeout: playwright.Float(500),
+	})
+	require.NoError(t, err)
+	require.True(t, enabled)
+}
+
+func TestLocatorPressSequentially(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<input>`))
+	_, err := page.Evaluate(`() => {
+		window['keys'] = [];
+		document.querySelector('input').addEventListener('keydown', e => window['keys'].push([e.key, performance.now()]));
+	}`)
+	require.NoError(t, err)
+	input := page.Locator("input")
+	require.NoError(t, input.PressSequentially("abc", playwright.LocatorPressSequentiallyOptions{
+		Delay: playwright.Float(50),
+	}))
+	value, err := input.InputValue()
+	require.NoError(t, err)
+	require.Equal(t, "abc", value)
+	keys, err := page.Evaluate(`window['keys'].map(([key]) => key)`)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"a", "b", "c"}, keys)
+	elapsed, err := playwright.EvaluateAs[float64](page, `window['keys'][2][1] - window['keys'][0][1]`)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, elapsed, 90.0)
+
+	err = page.Locator("textarea").PressSequentially("abc", playwright.LocatorPressSequentiallyOptions{
+		Timeout: playwright.Float(500),
+	})
+	require.ErrorIs(t, err, playwright.TimeoutError)
+}
+
+func TestLocatorClickWithModifierShouldOpenNewPage(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.SetContent(`<a href="/one-style.html">yo</a>`))
+	modifier := playwright.KeyboardModifierControl
+	if runtime.GOOS == "darwin" {
+		modifier = playwright.KeyboardModifierMeta
+	}
+	newPage, err := context.ExpectPage(func() error {
+		return page.Locator("a").Click(playwright.LocatorClickOptions{
+			Modifiers: []playwright.KeyboardModifier{*modifier},
+		})
+	})
+	require.NoError(t, err)
+	require.NoError(t, newPage.WaitForLoadState())
+	require.Equal(t, server.PREFIX+"/one-style.html", newPage.URL())
+	require.Equal(t, server.EMPTY_PAGE, page.URL())
+
+	err = page.Locator("a").Click(playwright.LocatorClickOptions{
+		Modifiers: []playwright.KeyboardModifier{"Ctrl"},
+	})
+	require.ErrorIs(t, err, playwright.ErrInvalidKeyboardModifier)
+}
+
+func TestLocatorPressEnterShouldSubmitForm(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.SetContent(`<form action="/grid.html"><input name="q"></form>`))
+	input := page.Locator("input")
+	require.NoError(t, input.Fill("foo"))
+	require.NoError(t, input.Press("Enter"))
+	require.NoError(t, page.WaitForURL("**/grid.html?q=foo"))
+}
+
+func TestLocatorPressEscapeShouldCancel(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+		<input>
+		<script>
+			window.cancelled = false;
+			document.querySelector('input').addEventListener('keydown', e => {
+				if (e.key === 'Escape') {
+					e.target.value = '';
+					window.cancelled = true;
+				}
+			});
+		</script>`))
+	input := page.Locator("input")
+	require.NoError(t, input.PressSequentially("draft"))
+	require.NoError(t, input.Press("Escape", playwright.LocatorPressOptions{
+		Delay: playwright.Float(10),
+	}))
+	value, err := input.InputValue()
+	require.NoError(t, err)
+	require.Equal(t, "", value)
+	cancelled, err := page.Evaluate(`window.cancelled`)
+	require.NoError(t, err)
+	require.Equal(t, true, cancelled)
+
+	err = page.Locator("textarea").Press("Enter", playwright.LocatorPressOptions{
+		Timeout: playwright.Float(500),
+	})
+	require.ErrorIs(t, err, playwright.TimeoutError)
+}
+
+func TestLocatorStrictModeViolationShouldDescribeMatches(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<button>one</button><button>two</button><button>three</button>`))
+	err := page.Locator("button").Click(playwright.LocatorClickOptions{
+		Timeout: playwright.Float(500),
+	})
+	var violation *playwright.StrictModeViolationError
+	require.ErrorAs(t, err, &violation)
+	require.Equal(t, 3, violation.Count)
+	require.Contains(t, violation.Selector, "button")
+	require.Len(t, violation.Matches, 3)
+	require.Contains(t, violation.Matches[0], "<button>one</button>")
+	require.Contains(t, err.Error(), "resolved to 3 elements")
+}
+
+func TestLocatorActionsShouldRetryWhenElementIsReRendered(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	// the button is replaced when the pointer enters it, a bounded number of times, so the first actions hit an
+	// element that is detached under them and the later ones a stable one
+	require.NoError(t, page.SetContent(`
+	<div id="root"></div>
+	<script>
+		window.clicks = 0;
+		window.renders = 0;
+		const render = () => {
+			const button = document.createElement('button');
+			button.textContent = 'Click me';
+			button.addEventListener('click', () => window.clicks++);
+			button.addEventListener('mouseover', () => {
+				if (window.renders < 2) {
+					window.renders++;
+					render();
+				}
+			}, { once: true });
+			document.getElementById('root').replaceChildren(button);
+		};
+		render();
+	</script>`))
+	button := page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Click me"})
+	for i := 0; i < 5; i++ {
+		require.NoError(t, button.Click(playwright.LocatorClickOptions{
+			Timeout: playwright.Float(5000),
+		}))
+	}
+	renders, err := page.Evaluate(`() => window.renders`)
+	require.NoError(t, err)
+	require.Greater(t, renders, 0)
+	// a click on a button replaced under the pointer may be swallowed, but once the renders are used up every click counts
+	clicks, err := page.Evaluate(`() => window.clicks`)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, clicks, 3)
+}
+
+func TestLocatorWaitForShouldWaitForToastToAppearAndDisappear(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div id="toast" hidden>Saved</div>`))
+	toast := page.Locator("#toast")
+	_, err := page.Evaluate(`() => {
+		const toast = document.getElementById('toast');
+		setTimeout(() => toast.hidden = false, 100);
+		setTimeout(() => toast.remove(), 300);
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, toast.WaitFor())
+	visible, err := toast.IsVisible()
+	require.NoError(t, err)
+	require.True(t, visible)
+	require.NoError(t, toast.WaitFor(playwright.LocatorWaitForOptions{
+		State: playwright.WaitForSelectorStateDetached,
+	}))
+	count, err := toast.Count()
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
+
+func TestLocatorWaitForShouldResolveImmediatelyWhenAlreadyInState(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div id="visible">hello</div><div id="hidden" style="display: none">world</div>`))
+	for _, tc := range []struct {
+		selector string
+		state    *playwright.WaitForSelectorState
+	}{
+		{"#visible", playwright.WaitForSelectorStateVisible},
+		{"#visible", playwright.WaitForSelectorStateAttached},
+		{"#hidden", playwright.WaitForSelectorStateHidden},
+		{"#hidden", playwright.WaitForSelectorStateAttached},
+		{"#missing", playwright.WaitForSelectorStateDetached},
+		{"#missing", playwright.WaitForSelectorStateHidden},
+	} {
+		require.NoError(t, page.Locator(tc.selector).WaitFor(playwright.LocatorWaitForOptions{
+			State:   tc.state,
+			Timeout: playwright.Float(100),
+		}), "%s %s", tc.selector, *tc.state)
+	}
+	err := page.Locator("#hidden").WaitFor(playwright.LocatorWaitForOptions{
+		State:   playwright.WaitForSelectorStateVisible,
+		Timeout: playwright.Float(100),
+	})
+	require.ErrorIs(t, err, playwright.TimeoutError)
+}
+
+func TestLocatorNthShouldAcceptNegativeIndices(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<table><tr><td>1</td></tr><tr><td>2</td></tr><tr><td>3</td></tr></table>`))
+	rows := page.Locator("tr")
+	for index, expected := range map[int]string{0: "1", 2: "3", -1: "3", -2: "2", -3: "1"} {
+		text, err := rows.Nth(index).TextContent()
+		require.NoError(t, err)
+		require.Equal(t, expected, text, "Nth(%d)", index)
+	}
+	text, err := rows.First().TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "1", text)
+	text, err = rows.Last().TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "3", text)
+}
+
+func TestLocatorNthShouldMatchNothingOutOfRange(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<ul><li>only</li></ul>`))
+	for _, locator := range []playwright.Locator{
+		page.Locator("li").Nth(1),
+		page.Locator("li").Nth(-2),
+		page.Locator("p").First(),
+		page.Locator("p").Last(),
+	} {
+		count, err := locator.Count()
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	}
+	_, err := page.Locator("p").Last().TextContent(playwright.LocatorTextContentOptions{
+		Timeout: playwright.Float(100),
+	})
+	require.ErrorIs(t, err, playwright.TimeoutError)
+}
+
+func TestLocatorLocatorShouldChainLocatorsWithOptions(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`
+	<table>
+		<tr><td class="name">apple</td><td class="price">1</td></tr>
+		<tr><td class="name">banana</td><td class="price">2</td></tr>
+	</table>`))
+	rows := page.Locator("tr")
+	price := page.Locator(".price")
+	text, err := rows.Locator(price, playwright.LocatorLocatorOptions{
+		HasText: "2",
+	}).TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "2", text)
+	count, err := rows.Locator("td", playwright.LocatorLocatorOptions{
+		HasNotText: regexp.MustCompile(`apple|banana`),
+	}).Count()
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	frame, err := utils.AttachFrame(page, "frame1", server.EMPTY_PAGE)
+	require.NoError(t, err)
+	_, err = page.Locator("body").Locator(frame.Locator("div")).Count()
+	require.ErrorIs(t, err, playwright.ErrLocatorNotSameFrame)
+	// the receiver stays usable after an invalid chain
+	count, err = page.Locator("body").Count()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
@@ -265,3 +265,121 @@ func TestNetworkEventsShouldFireEventsInProperOrder(t *testing.T) {
 	require.NoError(t, response.Finished())
 	require.Equal(t, []string{"request", "response", "requestfinished"}, events)
 }
+
+func TestPageOnRequestMatchingShouldOnlyFireForMatchingURLs(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/api/items", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	requests := []string{}
+	page.OnRequestMatching("**/api/**", func(request playwright.Request) {
+		requests = append(requests, request.URL())
+	})
+	responses := []int{}
+	page.OnResponseMatching(func(url string) bool {
+		return strings.HasSuffix(url, "/api/items")
+	}, func(response playwright.Response) {
+		responses = append(responses, response.Status())
+	})
+	_, err = page.Evaluate(`async () => {
+		await fetch('/one-style.css');
+		await fetch('/api/items');
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, []string{server.PREFIX + "/api/items"}, requests)
+	require.Equal(t, []int{200}, responses)
+}
+
+func TestRequestFailureShouldReportAbortReason(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.Route("**/aborted", func(route playwright.Route) {
+		require.NoError(t, route.Abort("aborted"))
+	}))
+	request, err := page.ExpectRequestFinished(func() error {
+		_, err := page.Evaluate(`() => fetch('/empty.html')`)
+		return err
+	})
+	require.NoError(t, err)
+	require.NoError(t, request.Failure())
+
+	failed, err := page.ExpectEvent("requestfailed", func() error {
+		_, err := page.Evaluate(`() => fetch('/aborted').catch(() => {})`)
+		return err
+	})
+	require.NoError(t, err)
+	failure := failed.(playwright.Request).Failure()
+	require.Error(t, failure)
+	if isChromium {
+		require.Equal(t, "net::ERR_ABORTED", failure.Error())
+	}
+}
+
+func TestResponseFinishedShouldReportFailureAfterHeaders(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	server.SetRoute("/truncated", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	})
+	response, err := page.ExpectResponse("**/truncated", func() error {
+		_, err := page.Evaluate(`() => { fetch('/truncated').then(r => r.text()).catch(() => {}) }`)
+		return err
+	})
+	require.NoError(t, err)
+	finished := response.Finished()
+	require.Error(t, finished)
+	require.Equal(t, response.Request().Failure(), finished)
+	// A finished response keeps reporting the same result.
+	require.Equal(t, finished, response.Finished())
+}
+
+func TestRequestShouldLinkFramesAndResponses(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	requests := make(chan playwright.Request, 10)
+	page.OnRequest(func(request playwright.Request) {
+		requests <- request
+	})
+	frame, err := utils.AttachFrame(page, "frame1", server.PREFIX+"/frames/frame.html")
+	require.NoError(t, err)
+
+	var document playwright.Request
+	for document == nil {
+		request := <-requests
+		if request.URL() == server.PREFIX+"/frames/frame.html" {
+			document = request
+		}
+	}
+	require.Equal(t, frame, document.Frame())
+	require.True(t, document.IsNavigationRequest())
+	require.Equal(t, "document", document.ResourceType())
+	response, err := document.Response()
+	require.NoError(t, err)
+	require.Equal(t, document, response.Request())
+	require.Equal(t, frame, response.Frame())
+
+	fetch, err := page.ExpectRequest("**/digits/1.png", func() error {
+		_, err := frame.Evaluate(`url => fetch(url)`, server.PREFIX+"/digits/1.png")
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, frame, fetch.Frame())
+	require.False(t, fetch.IsNavigationRequest())
+	require.Equal(t, "fetch", fetch.ResourceType())
+}
@@ -1,7 +1,10 @@
 package playwright_test
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"image/png"
 	"math/big"
 	"net/http"
 	"os"
@@ -9,6 +12,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -77,6 +81,79 @@ func TestPageScreenshot(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPageScreenshotShouldWaitForFonts(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.Route("**/iconfont.woff2", func(route playwright.Route) {
+		go func() {
+			time.Sleep(500 * time.Millisecond)
+			_ = route.Continue()
+		}()
+	}))
+	_, err = page.Evaluate(`() => {
+		document.body.innerHTML = '<span>+-</span>';
+		const font = new FontFace('pwtest-late', 'url(/webfont/iconfont.woff2)');
+		document.fonts.add(font);
+		font.load();
+		document.body.style.fontFamily = 'pwtest-late';
+	}`)
+	require.NoError(t, err)
+	status, err := page.Evaluate(`document.fonts.status`)
+	require.NoError(t, err)
+	require.Equal(t, "loading", status)
+
+	screenshot, err := page.Screenshot(playwright.PageScreenshotOptions{
+		WaitForFonts: playwright.Bool(true),
+		Animations:   playwright.ScreenshotAnimationsDisabled,
+	})
+	require.NoError(t, err)
+	require.True(t, filetype.IsImage(screenshot))
+	status, err = page.Evaluate(`document.fonts.status`)
+	require.NoError(t, err)
+	require.Equal(t, "loaded", status)
+
+	_, err = page.Locator("span").Screenshot(playwright.LocatorScreenshotOptions{
+		WaitForFonts: playwright.Bool(true),
+	})
+	require.NoError(t, err)
+}
+
+func TestPageScreenshotOfRegion(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetViewportSize(500, 500))
+	require.NoError(t, page.SetContent(`
+		<style>body { margin: 0 }</style>
+		<div style="height: 800px"></div>
+		<div id="box" style="margin-left: 50px; width: 100px; height: 40px; background: red"></div>
+		<div id="edge" style="position: absolute; left: 0; top: 0; width: 30px; height: 20px"></div>`))
+
+	screenshot, err := page.ScreenshotOfRegion(page.Locator("#box"), 10)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(screenshot))
+	require.NoError(t, err)
+	require.Equal(t, 120, img.Bounds().Dx())
+	require.Equal(t, 60, img.Bounds().Dy())
+	r, g, b, _ := img.At(60, 30).RGBA()
+	require.Equal(t, []uint32{0xffff, 0, 0}, []uint32{r, g, b})
+	r, g, b, _ = img.At(2, 2).RGBA()
+	require.Equal(t, []uint32{0xffff, 0xffff, 0xffff}, []uint32{r, g, b})
+
+	// padding is clipped at the top left corner of the viewport
+	require.NoError(t, page.Locator("#edge").ScrollIntoViewIfNeeded())
+	screenshot, err = page.ScreenshotOfRegion(page.Locator("#edge"), 10)
+	require.NoError(t, err)
+	img, err = png.Decode(bytes.NewReader(screenshot))
+	require.NoError(t, err)
+	require.Equal(t, 40, img.Bounds().Dx())
+	require.Equal(t, 30, img.Bounds().Dy())
+
+	_, err = page.ScreenshotOfRegion(page.Locator("#box"), -1)
+	require.Error(t, err)
+}
+
 func TestPagePDF(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -162,6 +239,29 @@ func TestPageEvaluate(t *testing.T) {
 	require.Equal(t, val, big.NewInt(17))
 }
 
+func TestPageEvaluateAs(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	title, err := playwright.EvaluateAs[string](page, `() => "hello"`)
+	require.NoError(t, err)
+	require.Equal(t, "hello", title)
+
+	ratio, err := playwright.EvaluateAs[float64](page, `a => a * 2`, 2)
+	require.NoError(t, err)
+	require.Equal(t, 4.0, ratio)
+
+	type point struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+	points, err := playwright.EvaluateAs[[]point](page.MainFrame(), `() => [{ x: 1, y: 2 }, { x: 3, y: 4 }]`)
+	require.NoError(t, err)
+	require.Equal(t, []point{{1, 2}, {3, 4}}, points)
+
+	_, err = playwright.EvaluateAs[int](page, `() => "not a number"`)
+	require.ErrorContains(t, err, "could not unmarshal JSON value")
+}
+
 func TestPageEvalOnSelectorAll(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -190,6 +290,35 @@ func TestPageEvalOnSelector(t *testing.T) {
 	require.Equal(t, val, "bar")
 }
 
+func TestPageEvalOnSelectorShouldAcceptJSHandleArgs(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<section><div class="foo">bar</div></section>`))
+	section, err := page.EvaluateHandle(`() => document.querySelector("section")`)
+	require.NoError(t, err)
+	//nolint:staticcheck
+	val, err := page.EvalOnSelector(".foo", `(element, section) => section.contains(element)`, section)
+	require.NoError(t, err)
+	require.Equal(t, true, val)
+	//nolint:staticcheck
+	val, err = page.EvalOnSelectorAll(".foo", `(elements, section) => elements.every(e => section.contains(e))`, section)
+	require.NoError(t, err)
+	require.Equal(t, true, val)
+}
+
+func TestPageEvalOnSelectorShouldErrorWhenNothingMatches(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div class="foo">bar</div>`))
+	//nolint:staticcheck
+	_, err := page.EvalOnSelector(".missing", `(element) => element.textContent`, nil)
+	require.ErrorContains(t, err, `Failed to find element matching selector ".missing"`)
+	//nolint:staticcheck
+	val, err := page.EvalOnSelectorAll(".missing", `(elements) => Array.isArray(elements) && elements.length`)
+	require.NoError(t, err)
+	require.Equal(t, 0, val)
+}
+
 func TestPageExpectWorker(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -281,9 +410,51 @@ func TestPageExpectPopup(t *testing.T) {
 	require.Equal(t, popup.URL(), server.EMPTY_PAGE)
 }
 func TestPageExpectNavigation(t *testing.T) {
-	t.Skip()
 	BeforeEach(t)
 	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+
+	t.Run("should match url and wait until", func(t *testing.T) {
+		//nolint:staticcheck
+		response, err := page.ExpectNavigation(func() error {
+			_, err := page.Evaluate(`url => { window.location.href = url }`, server.PREFIX+"/one-style.html")
+			return err
+		}, playwright.PageExpectNavigationOptions{
+			URL:       "**/one-style.html",
+			WaitUntil: playwright.WaitUntilStateDomcontentloaded,
+			Timeout:   playwright.Float(0),
+		})
+		require.NoError(t, err)
+		require.Equal(t, server.PREFIX+"/one-style.html", response.URL())
+	})
+
+	t.Run("should return nil response for pushState", func(t *testing.T) {
+		//nolint:staticcheck
+		response, err := page.ExpectNavigation(func() error {
+			_, err := page.Evaluate(`() => history.pushState({}, '', '/pushed.html')`)
+			return err
+		}, playwright.PageExpectNavigationOptions{
+			URL: regexp.MustCompile(`pushed\.html$`),
+		})
+		require.NoError(t, err)
+		require.Nil(t, response)
+		require.Equal(t, server.PREFIX+"/pushed.html", page.URL())
+	})
+
+	t.Run("should return a NavigationError for a failed navigation", func(t *testing.T) {
+		require.NoError(t, page.Route("**/aborted.html", func(route playwright.Route) {
+			require.NoError(t, route.Abort())
+		}))
+		//nolint:staticcheck
+		_, err := page.ExpectNavigation(func() error {
+			_, err := page.Evaluate(`url => { window.location.href = url }`, server.PREFIX+"/aborted.html")
+			return err
+		})
+		var navigationErr *playwright.NavigationError
+		require.ErrorAs(t, err, &navigationErr)
+		require.NotErrorIs(t, err, playwright.TimeoutError)
+	})
 }
 
 func TestPageExpectLoadState(t *testing.T) {
@@ -337,6 +508,22 @@ func TestPageExpectConsoleMessage(t *testing.T) {
 	require.Equal(t, message.Text(), "123 abc")
 }
 
+func TestPageExpectConsoleMessageWithPredicate(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<button onclick="console.log('first'); console.warn('clicked', 42)">Click</button>`))
+	message, err := page.ExpectConsoleMessage(func() error {
+		return page.Locator("button").Click()
+	}, playwright.PageExpectConsoleMessageOptions{
+		Predicate: func(m playwright.ConsoleMessage) bool {
+			return m.Type() == "warning"
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "clicked 42", message.Text())
+	require.Len(t, message.Args(), 2)
+}
+
 func TestPageExpectEvent(t *testing.T) {
 	t.Skip()
 	BeforeEach(t)
@@ -362,6 +549,28 @@ func TestPageOpener(t *testing.T) {
 	require.Nil(t, opener)
 }
 
+func TestPageOpenerShouldBeNilAfterOpenerCloses(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	opener, err := context.NewPage()
+	require.NoError(t, err)
+	_, err = opener.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	popup, err := opener.ExpectPopup(func() error {
+		_, err := opener.Evaluate("url => window.open(url)", server.EMPTY_PAGE)
+		return err
+	})
+	require.NoError(t, err)
+	got, err := popup.Opener()
+	require.NoError(t, err)
+	require.Equal(t, opener, got)
+
+	require.NoError(t, opener.Close())
+	got, err = popup.Opener()
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
+
 func TestPageTitle(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -371,6 +580,39 @@ func TestPageTitle(t *testing.T) {
 	require.Equal(t, "abc", title)
 }
 
+func TestPageTitleURLAndContentShouldMatchMainFrame(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.NoError(t, page.SetContent(`<!DOCTYPE html><title>original</title>`))
+	_, err = page.Evaluate(`() => {
+		document.title = 'changed';
+		history.replaceState({}, '', '/changed.html');
+		document.body.innerHTML = '<p id="added">added</p>';
+	}`)
+	require.NoError(t, err)
+
+	title, err := page.Title()
+	require.NoError(t, err)
+	require.Equal(t, "changed", title)
+	frameTitle, err := page.MainFrame().Title()
+	require.NoError(t, err)
+	require.Equal(t, frameTitle, title)
+
+	require.Equal(t, server.PREFIX+"/changed.html", page.URL())
+	require.Equal(t, page.MainFrame().URL(), page.URL())
+
+	content, err := page.Content()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(content, "<!DOCTYPE html>"), content)
+	require.Contains(t, content, `<title>changed</title>`)
+	require.Contains(t, content, `<p id="added">added</p>`)
+	frameContent, err := page.MainFrame().Content()
+	require.NoError(t, err)
+	require.Equal(t, frameContent, content)
+}
+
 func TestPageWaitForSelector(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -501,6 +743,44 @@ func TestPageAddStyleTagFile(t *testing.T) {
 	require.Equal(t, "rgb(255, 0, 0)", v)
 }
 
+func TestPageAddScriptTagShouldRequireExactlyOneSource(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+
+	_, err = page.AddScriptTag(playwright.PageAddScriptTagOptions{
+		URL:     playwright.String("injectedfile.js"),
+		Content: playwright.String("window.__injected = 1"),
+	})
+	require.EqualError(t, err, "exactly one of url, path or content must be specified")
+	_, err = page.AddStyleTag(playwright.PageAddStyleTagOptions{
+		Path:    playwright.String(Asset("injectedstyle.css")),
+		Content: playwright.String("body { color: red }"),
+	})
+	require.EqualError(t, err, "exactly one of url, path or content must be specified")
+	_, err = page.AddStyleTag(playwright.PageAddStyleTagOptions{})
+	require.EqualError(t, err, "exactly one of url, path or content must be specified")
+}
+
+func TestPageAddScriptTagModuleContent(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+
+	scriptHandle, err := page.AddScriptTag(playwright.PageAddScriptTagOptions{
+		Content: playwright.String("window.__module = 35"),
+		Type:    playwright.String("module"),
+	})
+	require.NoError(t, err)
+	scriptType, err := scriptHandle.GetAttribute("type")
+	require.NoError(t, err)
+	require.Equal(t, "module", *scriptType)
+	_, err = page.WaitForFunction("window.__module === 35", nil)
+	require.NoError(t, err)
+}
+
 func TestPageWaitForLoadState(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -606,13 +886,31 @@ func TestPageWaitForTimeout(t *testing.T) {
 	defer AfterEach(t)
 	before := time.Now()
 	//nolint:staticcheck
-	page.WaitForTimeout(1000)
+	require.NoError(t, page.WaitForTimeout(1000))
 	after := time.Now()
 	duration := after.Sub(before)
 	require.True(t, duration > time.Second)
 	require.True(t, duration < 2*time.Second)
 }
 
+func TestPageWaitForTimeoutShouldBeInterruptedWhenPageCloses(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	newPage, err := context.NewPage()
+	require.NoError(t, err)
+	errs := make(chan error, 1)
+	before := time.Now()
+	go func() {
+		//nolint:staticcheck
+		errs <- newPage.WaitForTimeout(30000)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, newPage.Close())
+	err = <-errs
+	require.True(t, playwright.IsSafeCloseError(err))
+	require.Less(t, time.Since(before), 10*time.Second)
+}
+
 func TestPageWaitForFunction(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -622,6 +920,59 @@ func TestPageWaitForFunction(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPageWaitForFunctionShouldPollOnInterval(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	start := time.Now()
+	handle, err := page.WaitForFunction(`() => {
+		window.__calls = (window.__calls || 0) + 1;
+		return window.__calls >= 3 ? window.__calls : false;
+	}`, nil, playwright.PageWaitForFunctionOptions{
+		Polling: 100,
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	value, err := handle.JSONValue()
+	require.NoError(t, err)
+	require.Equal(t, 3, value)
+
+	_, err = page.WaitForFunction(`() => true`, nil, playwright.PageWaitForFunctionOptions{
+		Polling: "raf",
+	})
+	require.NoError(t, err)
+	_, err = page.WaitForFunction(`() => true`, nil, playwright.PageWaitForFunctionOptions{
+		Polling: "sometimes",
+	})
+	require.ErrorContains(t, err, "polling must be")
+}
+
+func TestPageWaitForFunctionShouldRespectTimeout(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.WaitForFunction(`() => false`, nil, playwright.PageWaitForFunctionOptions{
+		Timeout: playwright.Float(100),
+	})
+	require.ErrorIs(t, err, playwright.TimeoutError)
+}
+
+func TestPageWaitForFunctionShouldAcceptElementHandleArg(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div id="target"></div>`))
+	//nolint:staticcheck
+	div, err := page.QuerySelector("#target")
+	require.NoError(t, err)
+	_, err = page.Evaluate(`() => setTimeout(() => document.querySelector('#target').classList.add('ready'), 100)`)
+	require.NoError(t, err)
+	handle, err := page.WaitForFunction(`element => element.classList.contains('ready') && element`, div)
+	require.NoError(t, err)
+	element := handle.AsElement()
+	require.NotNil(t, element)
+	id, err := element.GetAttribute("id")
+	require.NoError(t, err)
+	require.Equal(t, playwright.String("target"), id)
+}
+
 func TestPageDblclick(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -832,6 +1183,43 @@ func TestPagePageError(t *testing.T) {
 	}
 }
 
+func TestPageOnPageErrorShouldBeIndependentOfConsole(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	pageErrors := make(chan error, 2)
+	page.OnPageError(func(err error) {
+		pageErrors <- err
+	})
+	consoleErrors := playwright.CollectConsoleMessages(page, "error")
+	_, err := page.ExpectConsoleMessage(func() error {
+		_, err := page.Evaluate(`() => {
+			console.error("logged, not thrown");
+			setTimeout(() => { throw new TypeError("thrown, not logged"); }, 0);
+			setTimeout(() => { throw 42; }, 0);
+			setTimeout(() => console.log("done"), 10);
+		}`)
+		return err
+	}, playwright.PageExpectConsoleMessageOptions{
+		Predicate: func(message playwright.ConsoleMessage) bool {
+			return message.Text() == "done"
+		},
+	})
+	require.NoError(t, err)
+
+	first := <-pageErrors
+	var pwErr *playwright.Error
+	require.ErrorAs(t, first, &pwErr)
+	require.Equal(t, "TypeError", pwErr.Name)
+	require.Equal(t, "thrown, not logged", pwErr.Message)
+	require.Contains(t, pwErr.Stack, "thrown, not logged")
+	second := <-pageErrors
+	require.Equal(t, "42", second.Error())
+
+	messages := consoleErrors.Messages()
+	require.Len(t, messages, 1)
+	require.Equal(t, "logged, not thrown", messages[0].Text())
+}
+
 func TestPageSelectOption(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -1119,6 +1507,66 @@ func TestPageWaitForURL(t *testing.T) {
 	})
 }
 
+func TestPageIsClosedShouldBeSafeForConcurrentUse(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	newPage, err := context.NewPage()
+	require.NoError(t, err)
+	closed := make(chan bool, 1)
+	newPage.OnClose(func(p playwright.Page) {
+		closed <- p.IsClosed()
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !newPage.IsClosed() {
+				time.Sleep(time.Millisecond)
+			}
+		}()
+	}
+	require.False(t, newPage.IsClosed())
+	require.NoError(t, newPage.Close())
+	wg.Wait()
+	require.True(t, <-closed)
+}
+
+func TestPageCloseShouldRejectPendingOperationsWithSafeCloseError(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	newPage, err := context.NewPage()
+	require.NoError(t, err)
+	errs := make(chan error, 1)
+	go func() {
+		_, err := newPage.WaitForSelector("#never-appears")
+		errs <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, newPage.Close())
+	err = <-errs
+	require.True(t, playwright.IsSafeCloseError(err))
+	var closed *playwright.TargetClosedError
+	require.ErrorAs(t, err, &closed)
+	require.False(t, playwright.IsSafeCloseError(errors.New("unrelated failure")))
+}
+
+func TestPageCloseShouldReportReasonToSubsequentOperations(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	newPage, err := context.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, newPage.Close(playwright.PageCloseOptions{
+		Reason: playwright.String("The test is over"),
+	}))
+	require.True(t, newPage.IsClosed())
+	_, err = newPage.Goto(server.EMPTY_PAGE)
+	var closed *playwright.TargetClosedError
+	require.ErrorAs(t, err, &closed)
+	require.Equal(t, "The test is over", closed.Reason)
+	require.EqualError(t, err, "The test is over")
+}
+
 func TestCloseShouldRunBeforunloadIfAskedFor(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -1161,3 +1609,171 @@ func TestPageGotoShouldFailWhenExceedingBrowserContextNavigationTimeout(t *testi
 	require.ErrorContains(t, err, "Timeout 5ms exceeded.")
 	require.ErrorContains(t, err, "/empty.html")
 }
+
+func TestPageEmulateNetworkConditions(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	err = page.EmulateNetworkConditions(playwright.NetworkConditions{Offline: true})
+	if !isChromium {
+		require.ErrorContains(t, err, "only supported in Chromium")
+		return
+	}
+	require.NoError(t, err)
+	_, err = page.Evaluate(`url => fetch(url)`, server.EMPTY_PAGE)
+	require.Error(t, err)
+
+	require.NoError(t, page.EmulateNetworkConditions(playwright.NoNetworkThrottling))
+	status, err := page.Evaluate(`url => fetch(url).then(r => r.status)`, server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.Equal(t, 200, status)
+
+	require.NoError(t, page.EmulateNetworkConditions(playwright.NetworkConditions{
+		Latency:            500,
+		DownloadThroughput: -1,
+		UploadThroughput:   -1,
+	}))
+	start := time.Now()
+	_, err = page.Evaluate(`url => fetch(url, { cache: 'no-store' }).then(r => r.text())`, server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestPageGotoWaitUntilCommit(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/slow-subresource.png", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Second)
+		http.ServeFile(w, r, "assets/pptr.png")
+	})
+	server.SetRoute("/slow-load.html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<img src="/slow-subresource.png?` + r.URL.RawQuery + `">`))
+	})
+
+	start := time.Now()
+	response, err := page.Goto(server.PREFIX+"/slow-load.html?commit", playwright.PageGotoOptions{
+		WaitUntil: playwright.WaitUntilStateCommit,
+	})
+	require.NoError(t, err)
+	commit := time.Since(start)
+	require.True(t, response.Ok())
+	require.Equal(t, server.PREFIX+"/slow-load.html?commit", response.URL())
+
+	start = time.Now()
+	response, err = page.Goto(server.PREFIX + "/slow-load.html?load")
+	require.NoError(t, err)
+	load := time.Since(start)
+	require.True(t, response.Ok())
+
+	require.Less(t, commit, time.Second)
+	require.GreaterOrEqual(t, load, time.Second)
+}
+
+func TestPageOnRequestFailedAndFinishedShouldBeMutuallyExclusive(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/broken.css", func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	})
+	_, err := page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+
+	events := make(chan string, 4)
+	page.OnRequestFinished(func(request playwright.Request) {
+		events <- "finished " + request.URL()
+	})
+	page.OnRequestFailed(func(request playwright.Request) {
+		require.Error(t, request.Failure())
+		events <- "failed " + request.URL()
+	})
+	_, err = page.Evaluate(`async () => {
+		await fetch('/empty.html');
+		await fetch('/broken.css').catch(() => {});
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"finished " + server.EMPTY_PAGE,
+		"failed " + server.PREFIX + "/broken.css",
+	}, ChanToSlice(events, 2))
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected extra event: %s", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestPageQuerySelectorShouldNotWait(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div class="item">one</div>`))
+	_, err := page.Evaluate(`() => setTimeout(() => document.body.insertAdjacentHTML('beforeend', '<span>late</span>'), 200)`)
+	require.NoError(t, err)
+	//nolint:staticcheck
+	handle, err := page.QuerySelector("span")
+	require.NoError(t, err)
+	require.Nil(t, handle)
+	//nolint:staticcheck
+	handles, err := page.QuerySelectorAll("span")
+	require.NoError(t, err)
+	require.Len(t, handles, 0)
+	//nolint:staticcheck
+	handles, err = page.QuerySelectorAll(".item")
+	require.NoError(t, err)
+	require.Len(t, handles, 1)
+}
+
+func TestPageQuerySelectorShouldHonorStrictSelectors(t *testing.T) {
+	BeforeEach(t, playwright.BrowserNewContextOptions{
+		StrictSelectors: playwright.Bool(true),
+	})
+	defer AfterEach(t)
+	require.NoError(t, page.SetContent(`<div>one</div><div>two</div>`))
+	//nolint:staticcheck
+	_, err := page.QuerySelector("div")
+	require.ErrorContains(t, err, "strict mode violation")
+	//nolint:staticcheck
+	handle, err := page.QuerySelector("div", playwright.PageQuerySelectorOptions{
+		Strict: playwright.Bool(false),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, handle)
+	// QuerySelectorAll returns every match and is never strict
+	//nolint:staticcheck
+	handles, err := page.QuerySelectorAll("div")
+	require.NoError(t, err)
+	require.Len(t, handles, 2)
+}
+
+func TestPageSetExtraHTTPHeadersShouldOverrideContextHeaders(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	require.NoError(t, context.SetExtraHTTPHeaders(map[string]string{
+		"authorization": "context",
+		"x-context":     "1",
+	}))
+	anonymous, err := context.NewPage()
+	require.NoError(t, err)
+	require.NoError(t, page.SetExtraHTTPHeaders(map[string]string{
+		"authorization": "Bearer page",
+	}))
+
+	requestChan := server.WaitForRequestChan("/empty.html")
+	_, err = page.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	request := <-requestChan
+	require.Equal(t, "Bearer page", request.Header.Get("authorization"))
+	require.Equal(t, "1", request.Header.Get("x-context"))
+
+	// other pages of the context only get the context headers
+	requestChan = server.WaitForRequestChan("/empty.html")
+	_, err = anonymous.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	request = <-requestChan
+	require.Equal(t, "context", request.Header.Get("authorization"))
+	require.Equal(t, "1", request.Header.Get("x-context"))
+}
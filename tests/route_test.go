@@ -2,9 +2,12 @@ package playwright_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/playwright-community/playwright-go"
 	"github.com/stretchr/testify/require"
@@ -324,3 +327,51 @@ func TestRequestTimingShouldWork(t *testing.T) {
 	require.GreaterOrEqual(t, timing.ResponseEnd, timing.ResponseStart)
 	require.Less(t, timing.ResponseEnd, 10000.0)
 }
+
+func TestPageRouteBlockResourceTypes(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	server.SetRoute("/slow-image.png", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		http.ServeFile(w, r, "assets/pptr.png")
+	})
+	html := ""
+	for i := 0; i < 3; i++ {
+		html += fmt.Sprintf(`<img src="/slow-image.png?%d">`, i)
+	}
+	server.SetRoute("/images.html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(html))
+	})
+
+	start := time.Now()
+	_, err := page.Goto(server.PREFIX + "/images.html?unblocked")
+	require.NoError(t, err)
+	unblocked := time.Since(start)
+
+	var mu sync.Mutex
+	resourceTypes := map[string]string{}
+	require.NoError(t, page.Route("**/*", playwright.BlockResourceTypes("image", "font", "media")))
+	require.NoError(t, page.Route("**/*", func(route playwright.Route) {
+		mu.Lock()
+		resourceTypes[route.Request().URL()] = route.Request().ResourceType()
+		mu.Unlock()
+		require.NoError(t, route.Fallback())
+	}))
+	start = time.Now()
+	response, err := page.Goto(server.PREFIX + "/images.html?blocked")
+	require.NoError(t, err)
+	blocked := time.Since(start)
+	require.True(t, response.Ok())
+
+	t.Logf("page load: %v unblocked, %v with images blocked", unblocked, blocked)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, "document", resourceTypes[server.PREFIX+"/images.html?blocked"])
+	for i := 0; i < 3; i++ {
+		require.Equal(t, "image", resourceTypes[fmt.Sprintf("%s/slow-image.png?%d", server.PREFIX, i)])
+	}
+	complete, err := page.Evaluate(`() => [...document.images].every(img => img.complete && img.naturalWidth === 0)`)
+	require.NoError(t, err)
+	require.True(t, complete.(bool))
+}
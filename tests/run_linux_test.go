@@ -0,0 +1,65 @@
+package playwright_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+// driverProcesses returns the pids of the drivers started by this process.
+func driverProcesses(t *testing.T) []int {
+	t.Helper()
+	stats, err := filepath.Glob("/proc/[0-9]*/stat")
+	require.NoError(t, err)
+	pids := []int{}
+	for _, stat := range stats {
+		content, err := os.ReadFile(stat)
+		if err != nil {
+			// the process exited in the meantime
+			continue
+		}
+		// the command name may contain spaces, the fields after it do not: pid (comm) state ppid ...
+		fields := strings.Fields(string(content[bytes.LastIndexByte(content, ')')+1:]))
+		if len(fields) < 2 || fields[1] != strconv.Itoa(os.Getpid()) {
+			continue
+		}
+		cmdline, err := os.ReadFile(filepath.Join(filepath.Dir(stat), "cmdline"))
+		if err != nil || !bytes.Contains(cmdline, []byte("run-driver")) {
+			continue
+		}
+		pid, err := strconv.Atoi(filepath.Base(filepath.Dir(stat)))
+		require.NoError(t, err)
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+func TestRunSharedShouldReuseDriver(t *testing.T) {
+	before := driverProcesses(t)
+	pw1, err := playwright.RunShared()
+	require.NoError(t, err)
+	pw2, err := playwright.RunShared()
+	require.NoError(t, err)
+	require.Same(t, pw1, pw2)
+	require.Len(t, driverProcesses(t), len(before)+1)
+
+	require.NoError(t, pw1.Stop())
+	// the driver is still alive for the remaining user
+	request, err := pw2.Request.NewContext()
+	require.NoError(t, err)
+	require.NoError(t, request.Dispose())
+	require.Len(t, driverProcesses(t), len(before)+1)
+	require.NoError(t, pw2.Stop())
+	require.ElementsMatch(t, before, driverProcesses(t))
+
+	pw3, err := playwright.RunShared()
+	require.NoError(t, err)
+	require.NotSame(t, pw1, pw3)
+	require.NoError(t, pw3.Stop())
+}
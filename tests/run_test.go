@@ -0,0 +1,81 @@
+package playwright_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAsyncEventHandlersShouldNotBlockOtherEvents(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	pw2, err := playwright.Run(&playwright.RunOptions{AsyncEventHandlers: true})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, pw2.Stop())
+	}()
+	browserType2 := pw2.Chromium
+	if isFirefox {
+		browserType2 = pw2.Firefox
+	} else if isWebKit {
+		browserType2 = pw2.WebKit
+	}
+	browser2, err := browserType2.Launch()
+	require.NoError(t, err)
+	defer browser2.Close()
+	page2, err := browser2.NewPage()
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	messages := make(chan string, 3)
+	page2.OnConsole(func(message playwright.ConsoleMessage) {
+		<-release
+		messages <- message.Text()
+	})
+	requests := make(chan string, 1)
+	page2.OnRequest(func(request playwright.Request) {
+		requests <- request.URL()
+	})
+	_, err = page2.Evaluate(`() => { console.log("1"); console.log("2"); console.log("3") }`)
+	require.NoError(t, err)
+	// The blocked console handler does not hold up the navigation or its events.
+	_, err = page2.Goto(server.EMPTY_PAGE)
+	require.NoError(t, err)
+	require.Equal(t, server.EMPTY_PAGE, <-requests)
+	close(release)
+	require.Equal(t, "1", <-messages)
+	require.Equal(t, "2", <-messages)
+	require.Equal(t, "3", <-messages)
+}
+
+func TestRunEventReplayBufferShouldReplayEarlyResponses(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	pw2, err := playwright.Run(&playwright.RunOptions{EventReplayBufferSize: 8})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, pw2.Stop())
+	}()
+	browserType2 := pw2.Chromium
+	if isFirefox {
+		browserType2 = pw2.Firefox
+	} else if isWebKit {
+		browserType2 = pw2.WebKit
+	}
+	browser2, err := browserType2.Launch()
+	require.NoError(t, err)
+	defer browser2.Close()
+	page2, err := browser2.NewPage()
+	require.NoError(t, err)
+
+	_, err = page2.Goto(server.PREFIX + "/one-style.html")
+	require.NoError(t, err)
+	// Subscribed after the navigation, but still sees its responses.
+	urls := make(chan string, 8)
+	page2.(playwright.EventReplayer).OnWithReplay("response", func(response playwright.Response) {
+		urls <- response.URL()
+	})
+	require.Equal(t, server.PREFIX+"/one-style.html", <-urls)
+	require.Equal(t, server.PREFIX+"/one-style.css", <-urls)
+}
@@ -103,6 +103,48 @@ func TestSelectorsShouldUseDataTestIdInStrictErrors(t *testing.T) {
 	require.ErrorContains(t, err, `aka getByTestId('One')`)
 }
 
+func TestSelectorsSetTestIdAttributeShouldBeScopedToPlaywrightInstance(t *testing.T) {
+	BeforeEach(t)
+	defer AfterEach(t)
+	pw.Selectors.SetTestIdAttribute("data-custom-id")
+	defer pw.Selectors.SetTestIdAttribute("data-testid")
+
+	pw2, err := playwright.Run()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, pw2.Stop())
+	}()
+	browserType2 := pw2.Chromium
+	if isFirefox {
+		browserType2 = pw2.Firefox
+	} else if isWebKit {
+		browserType2 = pw2.WebKit
+	}
+	browser2, err := browserType2.Launch()
+	require.NoError(t, err)
+	page2, err := browser2.NewPage()
+	require.NoError(t, err)
+
+	content := `<div data-custom-id="custom">Custom</div><div data-testid="default">Default</div>`
+	require.NoError(t, page.SetContent(content))
+	require.NoError(t, page2.SetContent(content))
+
+	text, err := page.GetByTestId("custom").TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "Custom", text)
+	count, err := page.GetByTestId("default").Count()
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+
+	text, err = page2.GetByTestId("default").TextContent()
+	require.NoError(t, err)
+	require.Equal(t, "Default", text)
+	count, err = page2.GetByTestId("custom").Count()
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+	require.NoError(t, browser2.Close())
+}
+
 func TestSelectorsShouldWorkWithPath(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
@@ -100,7 +100,7 @@ func TestWorkershouldReportErrors(t *testing.T) {
 	BeforeEach(t)
 	defer AfterEach(t)
 	errChan := make(chan error, 1)
-	page.OnPageError(func(err *playwright.Error) {
+	page.OnPageError(func(err error) {
 		errChan <- err
 	})
 
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"sync"
 
@@ -18,6 +17,8 @@ type pipeTransport struct {
 	stdout    io.ReadCloser
 	onmessage func(msg *message)
 	rLock     sync.Mutex
+	stats     *connectionStats
+	logger    Logger
 }
 
 func (t *pipeTransport) Start() error {
@@ -31,6 +32,9 @@ func (t *pipeTransport) Start() error {
 			return fmt.Errorf("could not read padding: %w", err)
 		}
 		length := binary.LittleEndian.Uint32(lengthContent)
+		if t.stats != nil {
+			t.stats.bytesReceived.Add(int64(len(lengthContent)) + int64(length))
+		}
 
 		msg := &message{}
 		if err := json.NewDecoder(io.LimitReader(reader, int64(length))).Decode(&msg); err != nil {
@@ -39,7 +43,7 @@ func (t *pipeTransport) Start() error {
 		if os.Getenv("DEBUGP") != "" {
 			fmt.Fprint(os.Stdout, "\x1b[33mRECV>\x1b[0m\n")
 			if err := json.NewEncoder(os.Stdout).Encode(msg); err != nil {
-				log.Printf("could not encode json: %v", err)
+				t.logger.Errorf("could not encode json: %v", err)
 			}
 		}
 		t.onmessage(msg)
@@ -65,7 +69,7 @@ func (t *pipeTransport) Send(message map[string]interface{}) error {
 	if os.Getenv("DEBUGP") != "" {
 		fmt.Fprint(os.Stdout, "\x1b[32mSEND>\x1b[0m\n")
 		if err := json.NewEncoder(os.Stdout).Encode(message); err != nil {
-			log.Printf("could not encode json: %v", err)
+			t.logger.Errorf("could not encode json: %v", err)
 		}
 	}
 	lengthPadding := make([]byte, 4)
@@ -78,12 +82,16 @@ func (t *pipeTransport) Send(message map[string]interface{}) error {
 	if _, err = t.stdin.Write(msg); err != nil {
 		return err
 	}
+	if t.stats != nil {
+		t.stats.bytesSent.Add(int64(len(lengthPadding) + len(msg)))
+	}
 	return nil
 }
 
-func newPipeTransport(stdin io.WriteCloser, stdout io.ReadCloser) *pipeTransport {
+func newPipeTransport(stdin io.WriteCloser, stdout io.ReadCloser, logger Logger) *pipeTransport {
 	return &pipeTransport{
 		stdout: stdout,
 		stdin:  stdin,
+		logger: logger,
 	}
 }
@@ -66,6 +66,9 @@ func TestWaiterRejectOnTimeout(t *testing.T) {
 	}()
 	result, err := waiter.Wait()
 	require.ErrorContains(t, err, fmt.Sprintf("Timeout %.2fms exceeded.", timeout))
+	require.ErrorIs(t, err, TimeoutError)
+	var timeoutErr *TimeoutExceededError
+	require.ErrorAs(t, err, &timeoutErr)
 	require.Nil(t, result)
 }
 
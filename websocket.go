@@ -3,7 +3,6 @@ package playwright
 import (
 	"encoding/base64"
 	"errors"
-	"log"
 )
 
 type webSocketImpl struct {
@@ -51,7 +50,7 @@ func (ws *webSocketImpl) onFrameSent(opcode float64, data string) {
 	if opcode == 2 {
 		payload, err := base64.StdEncoding.DecodeString(data)
 		if err != nil {
-			log.Printf("could not decode WebSocket.onFrameSent payload: %v", err)
+			ws.connection.logger.Warnf("could not decode WebSocket.onFrameSent payload: %v", err)
 			return
 		}
 		ws.Emit("framesent", payload)
@@ -64,7 +63,7 @@ func (ws *webSocketImpl) onFrameReceived(opcode float64, data string) {
 	if opcode == 2 {
 		payload, err := base64.StdEncoding.DecodeString(data)
 		if err != nil {
-			log.Printf("could not decode WebSocket.onFrameReceived payload: %v", err)
+			ws.connection.logger.Warnf("could not decode WebSocket.onFrameReceived payload: %v", err)
 			return
 		}
 		ws.Emit("framereceived", payload)